@@ -0,0 +1,347 @@
+package core
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"regexp"
+	"strings"
+)
+
+// ArgConstraint is a rule from the args block about which args may be given together, or which values they may take.
+// Constraints are checked after args are parsed, before the script runs.
+type ArgConstraint interface {
+	// Violation describes how the args break the constraint, or is empty if they satisfy it.
+	Violation(args map[string]*CobraArg) string
+	// Describe summarizes the constraint, for the script's usage.
+	Describe() string
+}
+
+// ArgConstraints resolves the constraints in an args block against the args it declares, returning errors for
+// constraints which refer to undeclared args, or which can't apply to the args they name.
+func ArgConstraints(block ArgBlock) ([]ArgConstraint, []RslError) {
+	builder := &argConstraintBuilder{decls: make(map[string]ArgDeclaration)}
+	for _, stmt := range block.Stmts {
+		if decl, ok := stmt.(*ArgDeclaration); ok {
+			builder.decls[decl.Identifier.GetLexeme()] = *decl
+		}
+	}
+	for _, stmt := range block.Stmts {
+		stmt.Accept(builder)
+	}
+	return builder.constraints, builder.errors
+}
+
+type argConstraintBuilder struct {
+	decls       map[string]ArgDeclaration
+	constraints []ArgConstraint
+	errors      []RslError
+}
+
+func (b *argConstraintBuilder) VisitArgDeclarationArgStmt(ArgDeclaration) {
+}
+
+func (b *argConstraintBuilder) VisitArgRequiresArgStmt(requires ArgRequires) {
+	arg, ok := b.resolve(requires.Identifier)
+	required, allOk := b.resolveAll(requires.Required)
+	if ok && allOk {
+		b.constraints = append(b.constraints, requiresConstraint{arg: arg, required: required})
+	}
+}
+
+func (b *argConstraintBuilder) VisitArgOneOfArgStmt(oneOf ArgOneOf) {
+	args, ok := b.resolveAll(oneOf.Identifiers)
+	if ok {
+		b.constraints = append(b.constraints, countConstraint{kind: AT_MOST, count: 1, args: args})
+	}
+}
+
+func (b *argConstraintBuilder) VisitArgCountArgStmt(count ArgCount) {
+	args, ok := b.resolveAll(count.Identifiers)
+	if !ok {
+		return
+	}
+
+	kind := ALL_KEYWORDS[count.CountToken.GetLexeme()]
+	n := count.Count.Literal
+	if n < 0 || (kind != AT_MOST && n > int64(len(args))) {
+		b.error(&count.Count, fmt.Sprintf("'%s %d' can never be satisfied by %d args",
+			count.CountToken.GetLexeme(), n, len(args)))
+		return
+	}
+	b.constraints = append(b.constraints, countConstraint{kind: kind, count: n, args: args})
+}
+
+func (b *argConstraintBuilder) VisitArgRegexArgStmt(regex ArgRegex) {
+	args, ok := b.resolveAll(regex.Identifiers)
+	if !ok {
+		return
+	}
+
+	for idx, arg := range args {
+		if arg.argType != RslStringT && arg.argType != RslStringArrayT {
+			b.error(regex.Identifiers[idx], fmt.Sprintf("Regex constraints only apply to string args, but '%s' is %s",
+				arg.name, arg.argType.AsString()))
+			return
+		}
+	}
+
+	compiled, err := regexp.Compile(regex.Regex.Literal)
+	if err != nil {
+		b.error(&regex.Regex, fmt.Sprintf("Invalid regex: %v", err))
+		return
+	}
+
+	for _, arg := range args {
+		b.constraints = append(b.constraints, regexConstraint{arg: arg, regex: compiled, negated: regex.Negated})
+	}
+}
+
+func (b *argConstraintBuilder) VisitArgRangeArgStmt(rangeStmt ArgRange) {
+	arg, ok := b.resolve(rangeStmt.Identifier)
+	if !ok {
+		return
+	}
+
+	switch arg.argType {
+	case RslIntT, RslIntArrayT, RslFloatT, RslFloatArrayT:
+	default:
+		b.error(rangeStmt.Identifier, fmt.Sprintf("Range constraints only apply to int or float args, but '%s' is %s",
+			arg.name, arg.argType.AsString()))
+		return
+	}
+
+	var bound float64
+	switch coerced := rangeStmt.Bound.(type) {
+	case *IntLiteralToken:
+		bound = float64(coerced.Literal)
+	case *FloatLiteralToken:
+		bound = coerced.Literal
+	}
+	if rangeStmt.Negative {
+		bound = -bound
+	}
+
+	b.constraints = append(b.constraints, rangeConstraint{
+		arg:        arg,
+		comparator: rangeStmt.Comparator,
+		bound:      bound,
+		boundText:  lo.Ternary(rangeStmt.Negative, "-", "") + rangeStmt.Bound.GetLexeme(),
+	})
+}
+
+// constrainedArg is the part of an arg declaration that constraints need.
+type constrainedArg struct {
+	name    string
+	apiName string
+	argType RslTypeEnum
+}
+
+func (b *argConstraintBuilder) resolve(identifier Token) (constrainedArg, bool) {
+	name := identifier.GetLexeme()
+	decl, ok := b.decls[name]
+	if !ok {
+		b.errors = append(b.errors, RslError{
+			Token: identifier,
+			Msg:   fmt.Sprintf("Constraint refers to undeclared arg '%s'", name),
+			Hint:  didYouMean(name, lo.Keys(b.decls)),
+		})
+		return constrainedArg{}, false
+	}
+	return constrainedArg{name: name, apiName: argApiName(decl), argType: decl.ArgType.Type}, true
+}
+
+func (b *argConstraintBuilder) resolveAll(identifiers []Token) ([]constrainedArg, bool) {
+	allOk := true
+	var args []constrainedArg
+	for _, identifier := range identifiers {
+		arg, ok := b.resolve(identifier)
+		allOk = allOk && ok
+		args = append(args, arg)
+	}
+	return args, allOk
+}
+
+func (b *argConstraintBuilder) error(token Token, msg string) {
+	b.errors = append(b.errors, RslError{Token: token, Msg: msg})
+}
+
+type requiresConstraint struct {
+	arg      constrainedArg
+	required []constrainedArg
+}
+
+func (c requiresConstraint) Violation(args map[string]*CobraArg) string {
+	if !args[c.arg.name].IsGiven() {
+		return ""
+	}
+	missing := lo.Filter(c.required, func(required constrainedArg, _ int) bool {
+		return !args[required.name].IsGiven()
+	})
+	if len(missing) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("'%s' requires %s", c.arg.apiName, quotedApiNames(missing))
+}
+
+func (c requiresConstraint) Describe() string {
+	return fmt.Sprintf("'%s' requires %s", c.arg.apiName, quotedApiNames(c.required))
+}
+
+// countConstraint limits how many of a group of args may be given. 'one_of' is 'at_most 1'.
+type countConstraint struct {
+	kind  TokenType
+	count int64
+	args  []constrainedArg
+}
+
+func (c countConstraint) Violation(args map[string]*CobraArg) string {
+	given := lo.Filter(c.args, func(arg constrainedArg, _ int) bool {
+		return args[arg.name].IsGiven()
+	})
+	numGiven := int64(len(given))
+
+	var ok bool
+	switch c.kind {
+	case AT_LEAST:
+		ok = numGiven >= c.count
+	case EXACTLY:
+		ok = numGiven == c.count
+	default:
+		ok = numGiven <= c.count
+	}
+	if ok {
+		return ""
+	}
+
+	if numGiven == 0 {
+		return c.Describe() + ", but got none"
+	}
+	return fmt.Sprintf("%s, but got %s", c.Describe(), quotedApiNames(given))
+}
+
+func (c countConstraint) Describe() string {
+	if c.kind == AT_MOST && c.count == 1 {
+		return fmt.Sprintf("Only one of %s may be given", quotedApiNames(c.args))
+	}
+
+	var quantifier string
+	switch c.kind {
+	case AT_LEAST:
+		quantifier = "At least"
+	case EXACTLY:
+		quantifier = "Exactly"
+	default:
+		quantifier = "At most"
+	}
+	return fmt.Sprintf("%s %d of %s must be given", quantifier, c.count, quotedApiNames(c.args))
+}
+
+type regexConstraint struct {
+	arg     constrainedArg
+	regex   *regexp.Regexp
+	negated bool
+}
+
+func (c regexConstraint) Violation(args map[string]*CobraArg) string {
+	arg := args[c.arg.name]
+	if arg.IsNull {
+		return ""
+	}
+
+	var values []string
+	if arg.IsStringArray() {
+		values = arg.GetStringArray()
+	} else {
+		values = []string{arg.GetString()}
+	}
+
+	for _, value := range values {
+		if c.regex.MatchString(value) == c.negated {
+			return fmt.Sprintf("%s, but got %q", c.Describe(), arg.shown(value))
+		}
+	}
+	return ""
+}
+
+func (c regexConstraint) Describe() string {
+	verb := lo.Ternary(c.negated, "must not match", "must match")
+	return fmt.Sprintf("'%s' %s regex %q", c.arg.apiName, verb, c.regex.String())
+}
+
+type rangeConstraint struct {
+	arg        constrainedArg
+	comparator Token
+	bound      float64
+	boundText  string
+}
+
+func (c rangeConstraint) Violation(args map[string]*CobraArg) string {
+	arg := args[c.arg.name]
+	if arg.IsNull {
+		return ""
+	}
+
+	var values []interface{}
+	switch {
+	case arg.IsInt():
+		values = []interface{}{arg.GetInt()}
+	case arg.IsIntArray():
+		values = lo.Map(arg.GetIntArray(), func(v int64, _ int) interface{} { return v })
+	case arg.IsFloat():
+		values = []interface{}{arg.GetFloat()}
+	case arg.IsFloatArray():
+		values = lo.Map(arg.GetFloatArray(), func(v float64, _ int) interface{} { return v })
+	}
+
+	for _, value := range values {
+		var f float64
+		switch coerced := value.(type) {
+		case int64:
+			f = float64(coerced)
+		case float64:
+			f = coerced
+		}
+		if !c.holds(f) {
+			return fmt.Sprintf("%s, but got %v", c.Describe(), value)
+		}
+	}
+	return ""
+}
+
+func (c rangeConstraint) holds(value float64) bool {
+	switch c.comparator.GetType() {
+	case GREATER:
+		return value > c.bound
+	case GREATER_EQUAL:
+		return value >= c.bound
+	case LESS:
+		return value < c.bound
+	default:
+		return value <= c.bound
+	}
+}
+
+func (c rangeConstraint) Describe() string {
+	return fmt.Sprintf("'%s' must be %s %s", c.arg.apiName, c.comparator.GetLexeme(), c.boundText)
+}
+
+func quotedApiNames(args []constrainedArg) string {
+	quoted := lo.Map(args, func(arg constrainedArg, _ int) string { return "'" + arg.apiName + "'" })
+	return strings.Join(quoted, ", ")
+}
+
+// checkArgConstraints returns a description of each constraint the args violate.
+func checkArgConstraints(constraints []ArgConstraint, cobraArgs []*CobraArg) []string {
+	args := make(map[string]*CobraArg)
+	for _, arg := range cobraArgs {
+		args[arg.Arg.Name] = arg
+	}
+
+	var violations []string
+	for _, constraint := range constraints {
+		if violation := constraint.Violation(args); violation != "" {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArgConfig is a user's config file for a script, giving values for its args to fall back on when they're not
+// given on the command line, or by an environment variable.
+type ArgConfig struct {
+	Path   string
+	values map[string]interface{}
+}
+
+// LoadArgConfig reads the config for the script, e.g. ~/.config/rad/deploy.yaml for deploy.rsl, where each key is
+// the name of an arg. Returns an empty config if there's no such file.
+func LoadArgConfig(scriptName string) ArgConfig {
+	path := argConfigPath(scriptName)
+	config := ArgConfig{Path: path, values: make(map[string]interface{})}
+	if path == "" {
+		return config
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			RP.RadErrorExit(fmt.Sprintf("Could not read config file '%s': %v\n", path, err))
+		}
+		return config
+	}
+
+	if err := yaml.Unmarshal(contents, &config.values); err != nil {
+		RP.RadErrorExit(fmt.Sprintf("Could not parse config file '%s': %v\n", path, err))
+	}
+	return config
+}
+
+func argConfigPath(scriptName string) string {
+	configDir, _ := REnvVars.Lookup("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home := homeDir()
+		if home == "" {
+			return ""
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configDir, "rad", strings.TrimSuffix(scriptName, filepath.Ext(scriptName))+".yaml")
+}
+
+// applyFallback sets the arg from its environment variable, or failing that the user's config, making that the
+// value it defaults to. Which one the value came from is noted in the flag's usage.
+func (c *CobraArg) applyFallback(flag *pflag.Flag, config ArgConfig) {
+	if c.Arg.EnvVar != nil {
+		if value, ok := REnvVars.Lookup(*c.Arg.EnvVar); ok {
+			c.setFallback(flag, "$"+*c.Arg.EnvVar, value)
+			return
+		}
+	}
+
+	if value, ok := config.values[c.Arg.ApiName]; ok {
+		c.setFallback(flag, displayPath(config.Path), value)
+	}
+}
+
+func (c *CobraArg) setFallback(flag *pflag.Flag, source string, value interface{}) {
+	var err error
+	if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+		var values []string
+		switch coerced := value.(type) {
+		case []interface{}:
+			for _, v := range coerced {
+				values = append(values, fmt.Sprint(v))
+			}
+		case string:
+			values = strings.Split(coerced, ",")
+		default:
+			values = []string{fmt.Sprint(coerced)}
+		}
+		// replaced rather than set, as setting a slice flag appends if the user gives it again
+		err = sliceValue.Replace(values)
+	} else if _, isList := value.([]interface{}); isList {
+		c.printer.UsageErrorExit(fmt.Sprintf("Invalid '%s' value from %s: expected a single %s, got a list\n",
+			c.Arg.ApiName, source, c.Arg.Type.AsString()))
+	} else {
+		err = flag.Value.Set(fmt.Sprint(value))
+	}
+
+	if err != nil {
+		c.printer.UsageErrorExit(fmt.Sprintf("Invalid '%s' value from %s: expected %s, got %q\n",
+			c.Arg.ApiName, source, c.Arg.Type.AsString(), c.shown(fmt.Sprint(value))))
+	}
+
+	c.FallbackSource = source
+	if !c.Arg.IsSecret {
+		flag.DefValue = flag.Value.String()
+	}
+	flag.Usage = strings.TrimSpace(fmt.Sprintf("%s (from %s)", flag.Usage, source))
+}
+
+// displayPath abbreviates the home directory in the path to '~'.
+func displayPath(path string) string {
+	home := homeDir()
+	if home == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("~", rel)
+	}
+	return path
+}
+
+// homeDir is the user's home directory, like os.UserHomeDir, but read from REnvVars. Empty if it isn't set.
+func homeDir() string {
+	if home, ok := REnvVars.Lookup("HOME"); ok && home != "" {
+		return home
+	}
+	home, _ := REnvVars.Lookup("USERPROFILE")
+	return home
+}
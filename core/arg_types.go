@@ -10,6 +10,18 @@ type ScriptArg struct {
 	Type             RslTypeEnum
 	Description      *string
 	IsOptional       bool
+	// true if the arg's value is redacted wherever rad prints it
+	IsSecret bool
+	// the values the arg is restricted to, or nil if it may be anything
+	EnumValues []string
+	// keys of the resource the arg is used to pick from, offered when completing the arg in a shell
+	ResourceKeys []string
+	// PATH or DIR if the arg is completed as a path in a shell, else empty
+	PathType TokenType
+	// extensions of the files a path arg is completed with, or nil for any file
+	PathExtensions []string
+	// environment variable the arg falls back on, if not given on the command line
+	EnvVar *string
 	// first check the Type and IsOptional, then get the value
 	// todo I think just make these non-pointers, and have a separate flag to indicate the arg is set
 	DefaultString      *string
@@ -24,11 +36,7 @@ type ScriptArg struct {
 
 func FromArgDecl(l *LiteralInterpreter, argDecl *ArgDeclaration) *ScriptArg {
 	name := argDecl.Identifier.GetLexeme()
-	apiName := name
-	rename := argDecl.Rename
-	if NotNil(rename, func() Token { return nil }) {
-		apiName = (*rename).(*StringLiteralToken).Literal
-	}
+	apiName := argApiName(*argDecl)
 
 	var flag *string
 	flagToken := argDecl.Flag
@@ -53,13 +61,34 @@ func FromArgDecl(l *LiteralInterpreter, argDecl *ArgDeclaration) *ScriptArg {
 		Type:             argDecl.ArgType.Type,
 		Description:      comment,
 		IsOptional:       argDecl.IsOptional,
+		IsSecret:         argDecl.IsSecret,
+	}
+
+	if argDecl.EnvVar != nil {
+		scriptArg.EnvVar = &argDecl.EnvVar.Literal
+	}
+
+	if pathType := ARGS_BLOCK_KEYWORDS[argDecl.ArgType.Token.GetLexeme()]; pathType == PATH || pathType == DIR {
+		scriptArg.PathType = pathType
+	}
+	if argDecl.Extensions != nil {
+		for _, extension := range argDecl.Extensions.Values {
+			scriptArg.PathExtensions = append(scriptArg.PathExtensions, extension.Value.Literal)
+		}
+	}
+
+	if argDecl.Enum != nil {
+		for _, value := range argDecl.Enum.Values {
+			scriptArg.EnumValues = append(scriptArg.EnumValues, value.Value.Literal)
+		}
 	}
 
 	defaultVal := argDecl.Default
 	if NotNil(defaultVal, func() LiteralOrArray { return nil }) {
 		literal := (*defaultVal).Accept(l)
 		switch scriptArg.Type {
-		case RslStringT:
+		case RslStringT, RslDateT, RslDateTimeT, RslDurationT:
+			// time defaults are kept as written, and parsed when run
 			val := literal.(string)
 			scriptArg.DefaultString = &val
 		case RslStringArrayT:
@@ -110,3 +139,12 @@ func FromArgDecl(l *LiteralInterpreter, argDecl *ArgDeclaration) *ScriptArg {
 
 	return scriptArg
 }
+
+// argApiName is the name users give the arg by, which may be renamed from its name in the script.
+func argApiName(argDecl ArgDeclaration) string {
+	rename := argDecl.Rename
+	if NotNil(rename, func() Token { return nil }) {
+		return (*rename).(*StringLiteralToken).Literal
+	}
+	return argDecl.Identifier.GetLexeme()
+}
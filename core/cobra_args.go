@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"strconv"
 	"strings"
@@ -12,6 +13,11 @@ type CobraArg struct {
 	Arg     ScriptArg
 	value   interface{} // should be a pointer, e.g. *string . This is to allow cobra to set the value
 	IsNull  bool
+	// true if the user gave the arg, rather than it being defaulted
+	IsProvided bool
+	// where the arg's value came from if it wasn't given on the command line e.g. "$GH_TOKEN", empty if neither
+	// its environment variable nor the user's config had a value for it
+	FallbackSource string
 }
 
 func (c *CobraArg) IsString() bool {
@@ -42,8 +48,31 @@ func (c *CobraArg) IsBool() bool {
 	return c.Arg.Type == RslBoolT
 }
 
+// IsTime is true for date, datetime, and duration args.
+func (c *CobraArg) IsTime() bool {
+	return isTimeType(c.Arg.Type)
+}
+
+// IsGiven is true if the user gave the arg. Bools only count if true, as false is the same as not giving them.
+func (c *CobraArg) IsGiven() bool {
+	if !c.IsProvided || c.IsNull {
+		return false
+	}
+	if c.IsBool() {
+		return c.GetBool()
+	}
+	return true
+}
+
 func (c *CobraArg) InitializeOptional() {
-	if c.Arg.DefaultString != nil {
+	if c.IsTime() {
+		// time defaults are parsed when run, so relative ones e.g. "-7d" are relative to now
+		if c.Arg.DefaultString != nil {
+			c.setValue(*c.Arg.DefaultString)
+		} else {
+			c.IsNull = true
+		}
+	} else if c.Arg.DefaultString != nil {
 		c.value = c.Arg.DefaultString
 	} else if c.Arg.DefaultStringArray != nil {
 		c.value = c.Arg.DefaultStringArray
@@ -100,7 +129,68 @@ func (c *CobraArg) GetBool() bool {
 	return *c.value.(*bool)
 }
 
+// GetTimeValue returns the RslDate, RslDateTime, or RslDuration of a time arg.
+func (c *CobraArg) GetTimeValue() interface{} {
+	return c.value.(*timeArgValue).value
+}
+
 func (c *CobraArg) SetValue(arg string) {
+	c.setValue(arg)
+	c.CheckEnum()
+}
+
+// shown is how the arg's value is shown in messages, redacted if the arg is secret.
+func (c *CobraArg) shown(value string) string {
+	return lo.Ternary(c.Arg.IsSecret, REDACTED, value)
+}
+
+// CheckEnum errors if the arg is restricted to a set of values, and its value is not one of them.
+func (c *CobraArg) CheckEnum() {
+	if c.Arg.EnumValues == nil || c.IsNull {
+		return
+	}
+
+	var values []string
+	if c.IsStringArray() {
+		values = c.GetStringArray()
+	} else {
+		values = []string{c.GetString()}
+	}
+
+	for _, value := range values {
+		if !lo.Contains(c.Arg.EnumValues, value) {
+			c.printer.UsageErrorExit(fmt.Sprintf("Invalid '%s' value: %q. Valid values: %s\n",
+				c.Arg.ApiName, c.shown(value), strings.Join(c.Arg.EnumValues, ", ")))
+		}
+	}
+}
+
+// Complete offers values for the arg when completing it in a shell. Path and dir args are completed as files and
+// directories, but other args aren't, as their values are rarely paths.
+func (c *CobraArg) Complete(toComplete string) ([]string, cobra.ShellCompDirective) {
+	values := c.Arg.EnumValues
+	if values == nil {
+		values = c.Arg.ResourceKeys
+	}
+	if values != nil {
+		return lo.Filter(values, func(value string, _ int) bool {
+			return strings.HasPrefix(value, toComplete)
+		}), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	switch c.Arg.PathType {
+	case DIR:
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	case PATH:
+		if c.Arg.PathExtensions != nil {
+			return c.Arg.PathExtensions, cobra.ShellCompDirectiveFilterFileExt
+		}
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func (c *CobraArg) setValue(arg string) {
 	// do proper casting
 	switch c.Arg.Type {
 	case RslStringT:
@@ -110,7 +200,7 @@ func (c *CobraArg) SetValue(arg string) {
 		split := strings.Split(arg, ",")
 		c.value = &split
 	case RslIntT:
-		parsed, err := strconv.Atoi(arg)
+		parsed, err := strconv.ParseInt(arg, 10, 64)
 		if err != nil {
 			c.printer.TokenErrorExit(c.Arg.DeclarationToken, fmt.Sprintf("Expected int, but could not parse: %v\n", arg))
 		}
@@ -170,9 +260,42 @@ func (c *CobraArg) SetValue(arg string) {
 				c.printer.TokenErrorExit(c.Arg.DeclarationToken, fmt.Sprintf("Expected bool, but could not parse: %v\n", arg))
 			}
 		}
+		c.value = &bools
+	case RslDateT, RslDateTimeT, RslDurationT:
+		if err := c.value.(*timeArgValue).Set(arg); err != nil {
+			c.printer.UsageErrorExit(fmt.Sprintf("Invalid '%s' value: %v\n", c.Arg.ApiName, err))
+		}
 	}
 }
 
+// timeArgValue parses date, datetime, and duration args as they're set by cobra.
+type timeArgValue struct {
+	argType RslTypeEnum
+	value   interface{}
+	// the default as written e.g. "-7d", shown until a value is set, as it's only resolved when run
+	defaultText string
+}
+
+func (v *timeArgValue) Set(s string) error {
+	value, err := ParseTimeArg(v.argType, s)
+	if err != nil {
+		return err
+	}
+	v.value = value
+	return nil
+}
+
+func (v *timeArgValue) String() string {
+	if v.value == nil {
+		return v.defaultText
+	}
+	return ToPrintable(v.value)
+}
+
+func (v *timeArgValue) Type() string {
+	return v.argType.AsString()
+}
+
 func CreateCobraArg(printer Printer, cmd *cobra.Command, arg ScriptArg) CobraArg {
 	name, argType, flag, description := arg.ApiName, arg.Type, "", ""
 	if arg.Flag != nil {
@@ -181,6 +304,12 @@ func CreateCobraArg(printer Printer, cmd *cobra.Command, arg ScriptArg) CobraArg
 	if arg.Description != nil {
 		description = *arg.Description
 	}
+	if arg.EnumValues != nil {
+		description = strings.TrimSpace(fmt.Sprintf("%s (valid values: %s)", description, strings.Join(arg.EnumValues, ", ")))
+	}
+	if arg.EnvVar != nil {
+		description = strings.TrimSpace(fmt.Sprintf("%s (env: %s)", description, *arg.EnvVar))
+	}
 
 	var cobraArgValue interface{}
 	switch argType {
@@ -232,9 +361,23 @@ func CreateCobraArg(printer Printer, cmd *cobra.Command, arg ScriptArg) CobraArg
 			defVal = *arg.DefaultBoolArray
 		}
 		cobraArgValue = cmd.Flags().BoolSliceP(name, flag, defVal, description)
+	case RslDateT, RslDateTimeT, RslDurationT:
+		value := &timeArgValue{argType: argType}
+		if arg.DefaultString != nil {
+			value.defaultText = *arg.DefaultString
+		}
+		cobraArgValue = value
+		cmd.Flags().VarP(value, name, flag, description)
 	default:
 		printer.RadTokenErrorExit(arg.DeclarationToken, fmt.Sprintf("Unknown arg type: %v\n", argType))
 	}
+	if arg.IsSecret {
+		// keep defaults out of the usage
+		cmd.Flags().Lookup(name).DefValue = ""
+	}
 	cobraArg := CobraArg{printer: printer, Arg: arg, value: cobraArgValue}
+	cmd.RegisterFlagCompletionFunc(name, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cobraArg.Complete(toComplete)
+	})
 	return cobraArg
 }
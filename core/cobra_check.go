@@ -0,0 +1,103 @@
+package core
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	CHECK_CMD = "check"
+)
+
+func newCheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   CHECK_CMD + " <script>",
+		Short: "Check a script for errors without running it",
+		Long: `Lexes, parses, and statically analyzes a script without running it, printing any errors and warnings found.
+Exits non-zero if there are any errors, so it may be used in CI.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var rslSourceCode string
+			if stdinScriptName != "" {
+				SetScriptPath(stdinScriptName)
+				source, err := io.ReadAll(RIo.StdIn)
+				if err != nil {
+					RP.RadErrorExit(fmt.Sprintf("Could not read from stdin: %v\n", err))
+				}
+				rslSourceCode = string(source)
+			} else if len(args) == 0 {
+				cmd.Help()
+				return
+			} else {
+				SetScriptPath(args[0])
+				rslSourceCode = readSource(ScriptPath)
+			}
+			runCheck(rslSourceCode)
+		},
+	}
+}
+
+type checkDiagnostic struct {
+	RslError
+	isWarning bool
+}
+
+// runCheck prints every problem found in the script, sorted by where it occurs, and exits non-zero if any are errors.
+func runCheck(rslSourceCode string) {
+	RP.SetSource(ScriptPath, rslSourceCode)
+	l := NewLexer(RP, rslSourceCode)
+	l.Lex()
+
+	p := NewParser(RP, l.Tokens)
+	instructions := p.Parse()
+
+	checker := NewTypeChecker()
+	errs := checker.Check(instructions)
+	var diagnostics []checkDiagnostic
+	for _, err := range errs {
+		diagnostics = append(diagnostics, checkDiagnostic{RslError: err})
+	}
+	for _, warning := range checker.Warnings() {
+		diagnostics = append(diagnostics, checkDiagnostic{RslError: warning, isWarning: true})
+	}
+
+	if len(diagnostics) == 0 {
+		RP.Print(fmt.Sprintf("%s: No issues found\n", ScriptName))
+		return
+	}
+
+	sort.SliceStable(diagnostics, func(a, b int) bool {
+		lineA, charA := diagnosticPosition(diagnostics[a])
+		lineB, charB := diagnosticPosition(diagnostics[b])
+		if lineA != lineB {
+			return lineA < lineB
+		}
+		return charA < charB
+	})
+
+	source := newSourceFile(ScriptPath, rslSourceCode)
+	colored := shouldColor(RIo.StdOut)
+	rendered := make([]string, len(diagnostics))
+	for idx, diagnostic := range diagnostics {
+		severity := SEVERITY_ERROR
+		if diagnostic.isWarning {
+			severity = SEVERITY_WARNING
+		}
+		rendered[idx] = renderDiagnostic(source, severity, diagnostic.RslError, colored)
+	}
+	RP.Print(strings.Join(rendered, "\n"))
+	if len(errs) > 0 {
+		RExit(1)
+	}
+}
+
+// diagnosticPosition is where the diagnostic occurs, with those not tied to a token sorted first.
+func diagnosticPosition(diagnostic checkDiagnostic) (int, int) {
+	if diagnostic.Token == nil {
+		return 0, 0
+	}
+	return diagnostic.Token.GetLine(), diagnostic.Token.GetCharLineStart()
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+const (
+	COMPLETION_CMD = "completion"
+)
+
+func newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   COMPLETION_CMD + " <bash|zsh|fish>",
+		Short: "Generate a shell completion script for rad and its scripts",
+		Long: `Generates a completion script for the given shell. Once loaded, rad scripts have their args completed,
+including enum values, and keys of resources the args pick from.
+
+  bash: source <(rad completion bash)
+  zsh:  source <(rad completion zsh)
+  fish: rad completion fish | source`,
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		Run: func(cmd *cobra.Command, args []string) {
+			var sb strings.Builder
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				root.GenBashCompletionV2(&sb, true)
+			case "zsh":
+				root.GenZshCompletion(&sb)
+			case "fish":
+				root.GenFishCompletion(&sb, true)
+			}
+			RP.Print(sb.String())
+		},
+	}
+}
+
+// prepareCompletion modifies the command for the script being completed, if there is one, so its args can be
+// completed. The args are the command line being completed, the last one being the word under the cursor.
+func prepareCompletion(cmd *cobra.Command, args []string) {
+	if len(args) < 2 || strings.HasPrefix(args[0], "-") {
+		return
+	}
+	if info, err := os.Stat(args[0]); err != nil || info.IsDir() {
+		return
+	}
+
+	SetScriptPath(args[0])
+	rslSourceCode := readSource(ScriptPath)
+	RP.SetSource(ScriptPath, rslSourceCode)
+	l := NewLexer(RP, rslSourceCode)
+	l.Lex()
+
+	p := NewParser(RP, l.Tokens)
+	instructions := p.Parse()
+
+	scriptMetadata := ExtractMetadata(instructions)
+	addResourceCompletions(scriptMetadata.Args, l.Tokens)
+	modifyCmd(cmd, ScriptName, scriptMetadata, instructions)
+	rootModified = true
+}
+
+// addResourceCompletions finds args used to pick from a resource e.g. pick_from_resource("urls.json", site), and
+// completes them with the resource's keys. Only resources with literal paths can be found.
+func addResourceCompletions(args []ScriptArg, tokens []Token) {
+	for idx := 0; idx+5 < len(tokens); idx++ {
+		if tokens[idx].GetType() != IDENTIFIER || tokens[idx].GetLexeme() != PICK_FROM_RESOURCE ||
+			tokens[idx+1].GetType() != LEFT_PAREN ||
+			tokens[idx+2].GetType() != STRING_LITERAL ||
+			tokens[idx+3].GetType() != COMMA ||
+			tokens[idx+4].GetType() != IDENTIFIER ||
+			tokens[idx+5].GetType() != RIGHT_PAREN {
+			continue
+		}
+
+		argName := tokens[idx+4].GetLexeme()
+		for argIdx := range args {
+			if args[argIdx].Name != argName {
+				continue
+			}
+			resource, err := readPickResource(tokens[idx+2].(*StringLiteralToken).Literal)
+			if err != nil {
+				RP.RadDebug(fmt.Sprintf("Could not complete '%s' from resource: %v", argName, err))
+				continue
+			}
+			for _, option := range resource.Options {
+				args[argIdx].ResourceKeys = append(args[argIdx].ResourceKeys, option.Keys...)
+			}
+		}
+	}
+}
+
+// completePositional offers values for the next positional arg.
+func completePositional(cmd *cobra.Command, cobraArgs []*CobraArg, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if stdinScriptName == "" && len(args) > 0 {
+		// the script path
+		args = args[1:]
+	}
+
+	posArgsIndex := 0
+	for _, cobraArg := range cobraArgs {
+		if cmd.Flags().Lookup(cobraArg.Arg.ApiName).Changed {
+			continue
+		}
+		if posArgsIndex == len(args) {
+			return cobraArg.Complete(toComplete)
+		}
+		posArgsIndex++
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
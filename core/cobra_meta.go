@@ -8,6 +8,7 @@ import (
 
 type ScriptMetadata struct {
 	Args               []ScriptArg
+	Constraints        []ArgConstraint
 	OneLineDescription *string
 	BlockDescription   *string
 }
@@ -46,9 +47,11 @@ func LongDescription(metadata ScriptMetadata) string {
 
 func ExtractMetadata(statements []Stmt) ScriptMetadata {
 	args := extractArgs(statements)
+	constraints := extractConstraints(statements)
 	oneLineDescription, blockDescription := extractDescriptions(statements)
 	return ScriptMetadata{
 		Args:               args,
+		Constraints:        constraints,
 		OneLineDescription: oneLineDescription,
 		BlockDescription:   blockDescription,
 	}
@@ -93,3 +96,34 @@ func extractArgs(statements []Stmt) []ScriptArg {
 
 	return args
 }
+
+func extractConstraints(statements []Stmt) []ArgConstraint {
+	argBlockIfFound, ok := lo.Find(statements, func(stmt Stmt) bool {
+		_, ok := stmt.(*ArgBlock)
+		return ok
+	})
+
+	if !ok {
+		return nil
+	}
+
+	constraints, errs := ArgConstraints(*argBlockIfFound.(*ArgBlock))
+	if len(errs) > 0 {
+		RP.TokenErrorsExit(errs)
+	}
+	return constraints
+}
+
+// ConstraintsUsage lists the arg constraints, to be shown after the flags in the script's usage.
+func ConstraintsUsage(constraints []ArgConstraint) string {
+	if len(constraints) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\nConstraints:\n")
+	for _, constraint := range constraints {
+		sb.WriteString(fmt.Sprintf("  %s\n", constraint.Describe()))
+	}
+	return sb.String()
+}
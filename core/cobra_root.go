@@ -8,6 +8,8 @@ import (
 	"github.com/spf13/pflag"
 	"io"
 	"os"
+	"slices"
+	"strings"
 )
 
 var (
@@ -19,10 +21,18 @@ func NewRootCmd(cmdInput CmdInput) *cobra.Command {
 	rootModified = false
 
 	return &cobra.Command{
-		Use:     "",
+		Use:     "rad",
 		Short:   "Request And Display (RAD)",
 		Long:    `Request And Display (RAD): A tool for making HTTP requests, extracting details, and displaying the result.`,
 		Version: "0.3.8",
+		Args:    cobra.ArbitraryArgs,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			// the script to run, which will be modified into the command once given
+			return nil, cobra.ShellCompDirectiveDefault
+		},
+		CompletionOptions: cobra.CompletionOptions{
+			DisableDefaultCmd: true,
+		},
 		FParseErrWhitelist: cobra.FParseErrWhitelist{
 			UnknownFlags: true,
 		},
@@ -30,13 +40,8 @@ func NewRootCmd(cmdInput CmdInput) *cobra.Command {
 			if RP == nil {
 				RP = NewPrinter(cmd, shellFlag, quietFlag, debugFlag, radDebugFlag)
 			}
-			if !rootModified {
-				RP.RadDebug(fmt.Sprintf("Args passed: %v", args))
-				if radDebugFlag {
-					cmd.Flags().VisitAll(func(flag *pflag.Flag) {
-						RP.RadDebug(fmt.Sprintf("Flag %s: %v", flag.Name, flag.Value))
-					})
-				}
+			if cmd.Name() == cobra.ShellCompRequestCmd && !rootModified {
+				prepareCompletion(cmd.Root(), args)
 			}
 		},
 		Run: func(cmd *cobra.Command, args []string) {
@@ -73,6 +78,7 @@ func NewRootCmd(cmdInput CmdInput) *cobra.Command {
 }
 
 func extractMetadataAndModifyCmd(cmd *cobra.Command, rslSourceCode string) {
+	RP.SetSource(ScriptPath, rslSourceCode)
 	l := NewLexer(RP, rslSourceCode)
 	l.Lex()
 
@@ -91,6 +97,11 @@ func modifyCmd(cmd *cobra.Command, scriptName string, scriptMetadata ScriptMetad
 	cmd.Short = ShortDescription(scriptMetadata)
 	cmd.Long = LongDescription(scriptMetadata)
 	cmd.FParseErrWhitelist = cobra.FParseErrWhitelist{} // re-enable erroring on unknown flags. note: maybe remove for 'catchall' args?
+	cmd.ResetCommands()                                 // subcommands like 'check' belong to rad, not the script
+	if usage := ConstraintsUsage(scriptMetadata.Constraints); usage != "" {
+		// quoted as a template string, so constraints like regexes are printed verbatim
+		cmd.SetUsageTemplate(cmd.UsageTemplate() + fmt.Sprintf("{{%q}}", usage))
+	}
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		// fill in positional args, and
 		// error if required args are missing
@@ -100,15 +111,30 @@ func modifyCmd(cmd *cobra.Command, scriptName string, scriptMetadata ScriptMetad
 			args = args[1:]
 		}
 		var missingArgs []string
+		var secretsToPrompt []*CobraArg
+		shownArgs := slices.Clone(args)
+		secretFlags := make(map[string]bool)
 		for _, cobraArg := range cobraArgs {
 			argName := cobraArg.Arg.ApiName
 			cobraFlag := cmd.Flags().Lookup(argName)
-			if !cobraFlag.Changed {
+			secretFlags[argName] = cobraArg.Arg.IsSecret
+			cobraArg.IsProvided = cobraFlag.Changed
+			if cobraFlag.Changed {
+				cobraArg.CheckEnum()
+			} else {
 				// flag has not been explicitly set by the user
 				if posArgsIndex < len(args) {
 					// there's a positional arg to fill it
 					cobraArg.SetValue(args[posArgsIndex])
+					cobraArg.IsProvided = true
+					if cobraArg.Arg.IsSecret {
+						shownArgs[posArgsIndex] = REDACTED
+					}
 					posArgsIndex++
+				} else if cobraArg.FallbackSource != "" {
+					// already set from its environment variable or the user's config
+					cobraArg.IsProvided = true
+					cobraArg.CheckEnum()
 				} else if cobraArg.Arg.IsOptional {
 					// there's no positional arg to fill it, but that's okay because it's optional, so continue
 					// but first, fill in the optional's default value if it exists
@@ -118,6 +144,9 @@ func modifyCmd(cmd *cobra.Command, scriptName string, scriptMetadata ScriptMetad
 					// all bools are implicitly optional and default false, unless explicitly defaulted to true
 					// this branch implies it was not defaulted to true
 					cobraArg.SetValue("false")
+				} else if cobraArg.Arg.IsSecret && isInteractive() {
+					// asked for once we know nothing else is missing
+					secretsToPrompt = append(secretsToPrompt, cobraArg)
 				} else {
 					missingArgs = append(missingArgs, argName)
 				}
@@ -138,7 +167,29 @@ func modifyCmd(cmd *cobra.Command, scriptName string, scriptMetadata ScriptMetad
 			RP.UsageErrorExit(fmt.Sprintf("Too many positional arguments. Unused: %v\n", args[posArgsIndex:]))
 		}
 
+		for _, cobraArg := range secretsToPrompt {
+			cobraArg.SetValue(promptForSecret(cobraArg.Arg))
+			cobraArg.IsProvided = true
+		}
+
+		// only now that it's known which args are secret can they be redacted
+		RP.RadDebug(fmt.Sprintf("Args passed: %v", shownArgs))
+		if radDebugFlag {
+			cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				value := lo.Ternary[interface{}](secretFlags[flag.Name], REDACTED, flag.Value)
+				RP.RadDebug(fmt.Sprintf("Flag %s: %v", flag.Name, value))
+			})
+		}
+
+		if violations := checkArgConstraints(scriptMetadata.Constraints, cobraArgs); len(violations) > 0 {
+			RP.UsageErrorExit(strings.Join(violations, "\n") + "\n")
+		}
+
 		color.NoColor = noColorFlag
+		if errs := NewTypeChecker().Check(instructions); len(errs) > 0 {
+			RP.TokenErrorsExit(errs)
+		}
+		usePickSelections()
 		interpreter := NewInterpreter(instructions)
 		interpreter.InitArgs(cobraArgs)
 		interpreter.Run()
@@ -146,6 +197,10 @@ func modifyCmd(cmd *cobra.Command, scriptName string, scriptMetadata ScriptMetad
 		if shellFlag {
 			env := interpreter.env
 			for varName, val := range env.Vars {
+				if hasSecrets(val.value) {
+					RP.RadDebug(fmt.Sprintf("Not exporting %s, as it contains a secret", varName))
+					continue
+				}
 				// todo handle different types specifically
 				RP.PrintForShellEval(fmt.Sprintf("export %s=\"%v\"\n", varName, val.value))
 			}
@@ -156,9 +211,17 @@ func modifyCmd(cmd *cobra.Command, scriptName string, scriptMetadata ScriptMetad
 		cobraArg := CreateCobraArg(RP, cmd, arg)
 		cobraArgs = append(cobraArgs, &cobraArg)
 	}
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completePositional(cmd, cobraArgs, args, toComplete)
+	}
 
 	// hide global flags, that distract from the particular script
 	hideGlobalFlags(cmd)
+
+	config := LoadArgConfig(scriptName)
+	for _, cobraArg := range cobraArgs {
+		cobraArg.applyFallback(cmd.Flags().Lookup(cobraArg.Arg.ApiName), config)
+	}
 }
 
 func readSource(scriptPath string) string {
@@ -189,11 +252,12 @@ func InitCmd(cmd *cobra.Command) {
 		if len(args) >= 2 {
 			if lo.Some(args[1:], []string{"-h", "--help"}) && stdinScriptName == "" {
 				// it has, and with a rsl file source, so let's modify the cmd and re-run the root again
-				SetScriptPath(stdinScriptName)
+				SetScriptPath(args[0])
 				rslSourceCode := readSource(ScriptPath)
 				extractMetadataAndModifyCmd(cmd, rslSourceCode)
 			} else if stdinScriptName != "" {
 				// it has, and with reading rsl from stdin, so let's modify the cmd and re-run the root again
+				SetScriptPath(stdinScriptName)
 				source, err := io.ReadAll(RIo.StdIn)
 				if err == nil {
 					extractMetadataAndModifyCmd(cmd, string(source))
@@ -213,6 +277,7 @@ func InitCmd(cmd *cobra.Command) {
 	})
 
 	defineGlobalFlags(cmd)
+	cmd.AddCommand(newCheckCmd(), newCompletionCmd())
 	cmd.SetOut(RIo.StdErr)
 }
 
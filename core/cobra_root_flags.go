@@ -10,6 +10,7 @@ var (
 	radDebugFlag    bool
 	mockResponses   MockResponseSlice
 	noColorFlag     bool
+	pickFlag        []string
 )
 
 func defineGlobalFlags(cmd *cobra.Command) {
@@ -23,6 +24,7 @@ func defineGlobalFlags(cmd *cobra.Command) {
 	// todo help prints as `--MOCK-RESPONSE mockResponse` which is not ideal
 	cmd.PersistentFlags().Var(&mockResponses, "MOCK-RESPONSE", "Add mock response for json requests (pattern:filePath)")
 	cmd.PersistentFlags().BoolVar(&noColorFlag, "NO-COLOR", false, "Disable colorized output")
+	cmd.PersistentFlags().StringArrayVar(&pickFlag, "PICK", nil, "Selection for the next pick, instead of prompting. Repeatable, used in order")
 }
 
 func hideGlobalFlags(cmd *cobra.Command) {
@@ -35,4 +37,5 @@ func hideGlobalFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().MarkHidden("RAD-DEBUG")
 	cmd.PersistentFlags().MarkHidden("MOCK-RESPONSE")
 	cmd.PersistentFlags().MarkHidden("NO-COLOR")
+	cmd.PersistentFlags().MarkHidden("PICK")
 }
@@ -19,4 +19,5 @@ const (
 	PPRINT             = "pprint"
 	DEBUG              = "debug"
 	EXIT               = "exit"
+	RANGE              = "range"
 )
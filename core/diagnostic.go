@@ -0,0 +1,204 @@
+package core
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/lithammer/fuzzysearch/fuzzy"
+	"golang.org/x/term"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	SEVERITY_ERROR   = "error"
+	SEVERITY_WARNING = "warning"
+	// a column just past the end of the line, for tokens like newlines
+	END_OF_LINE = -1
+)
+
+// sourceFile is a script's path and contents, for rendering excerpts of it in diagnostics.
+type sourceFile struct {
+	path  string
+	lines []string
+}
+
+func newSourceFile(path string, source string) sourceFile {
+	return sourceFile{path: path, lines: strings.Split(source, "\n")}
+}
+
+type diagnosticRenderer struct {
+	source   sourceFile
+	colored  bool
+	severity string
+}
+
+// renderDiagnostics renders each error with an excerpt of the source it's in, e.g.
+//
+//	error: Undefined variable referenced: Autor
+//	 --> script.rsl:3:7
+//	3 | print(Autor)
+//	  |       ^^^^^
+//	  = help: did you mean `Author`?
+func renderDiagnostics(source sourceFile, severity string, errs []RslError, colored bool) string {
+	r := diagnosticRenderer{source: source, colored: colored, severity: severity}
+	rendered := make([]string, len(errs))
+	for idx, err := range errs {
+		rendered[idx] = r.render(err)
+	}
+	return strings.Join(rendered, "\n")
+}
+
+func renderDiagnostic(source sourceFile, severity string, err RslError, colored bool) string {
+	return renderDiagnostics(source, severity, []RslError{err}, colored)
+}
+
+func (r diagnosticRenderer) render(err RslError) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s\n", r.paint(r.severityColor(), r.severity+":"), err.Msg))
+	if err.Token == nil {
+		r.writeNotes(&sb, "", err)
+		return sb.String()
+	}
+
+	startLine, startCol, endLine, endCol := r.tokenSpan(err.Token)
+	sb.WriteString(fmt.Sprintf(" %s %s:%d:%d\n", r.paint(color.FgBlue, "-->"), r.source.path, startLine, startCol))
+	if startLine < 1 || endLine > len(r.source.lines) {
+		r.writeNotes(&sb, " ", err)
+		return sb.String()
+	}
+
+	gutterWidth := len(fmt.Sprintf("%d", endLine))
+	gutter := strings.Repeat(" ", gutterWidth)
+	for line := startLine; line <= endLine; line++ {
+		sourceLine := r.line(line)
+		from := 1
+		if line == startLine {
+			from = startCol
+		}
+		to := len([]rune(sourceLine))
+		if line == endLine {
+			to = endCol
+		}
+
+		sb.WriteString(fmt.Sprintf("%s %s %s\n", r.paint(color.FgBlue, fmt.Sprintf("%*d", gutterWidth, line)),
+			r.paint(color.FgBlue, "|"), sourceLine))
+		underline := strings.Repeat("^", max(to-from+1, 1))
+		sb.WriteString(fmt.Sprintf("%s %s %s%s\n", gutter, r.paint(color.FgBlue, "|"),
+			caretIndent(sourceLine, from), r.paint(r.severityColor(), underline)))
+	}
+	r.writeNotes(&sb, gutter, err)
+	return sb.String()
+}
+
+func (r diagnosticRenderer) writeNotes(sb *strings.Builder, gutter string, err RslError) {
+	if err.Note != "" {
+		sb.WriteString(fmt.Sprintf("%s %s %s\n", gutter, r.paint(color.FgBlue, "="), "note: "+err.Note))
+	}
+	if err.Hint != "" {
+		sb.WriteString(fmt.Sprintf("%s %s %s\n", gutter, r.paint(color.FgBlue, "="), r.paint(color.FgGreen, "help:")+" "+err.Hint))
+	}
+}
+
+func (r diagnosticRenderer) line(line int) string {
+	return strings.TrimSuffix(r.source.lines[line-1], "\r")
+}
+
+// tokenSpan returns the 1-indexed lines and columns on which the token starts and ends, inclusive.
+// Newline tokens span the end of the line they terminate.
+func (r diagnosticRenderer) tokenSpan(token Token) (int, int, int, int) {
+	line := token.GetLine()
+	lexeme := token.GetLexeme()
+
+	if lexeme == "\n" {
+		// the lexer counts newline tokens as being at the start of the following line
+		line--
+		col := END_OF_LINE
+		if line >= 1 && line <= len(r.source.lines) {
+			col = len([]rune(r.line(line))) + 1
+		}
+		return line, col, line, col
+	}
+
+	// multi-line tokens are placed on the line they end on
+	numLines := strings.Count(lexeme, "\n")
+	endCol := token.GetCharLineStart()
+	if numLines == 0 {
+		startCol := max(endCol-len([]rune(lexeme))+1, 1)
+		return line, startCol, line, max(endCol, startCol)
+	}
+
+	startLine := line - numLines
+	startCol := 1
+	if startLine >= 1 && startLine <= len(r.source.lines) {
+		firstSegment := lexeme[:strings.Index(lexeme, "\n")]
+		startCol = max(len([]rune(r.line(startLine)))-len([]rune(firstSegment))+1, 1)
+	}
+	return startLine, startCol, line, endCol
+}
+
+func (r diagnosticRenderer) severityColor() color.Attribute {
+	if r.severity == SEVERITY_WARNING {
+		return color.FgYellow
+	}
+	return color.FgRed
+}
+
+func (r diagnosticRenderer) paint(attribute color.Attribute, s string) string {
+	if !r.colored {
+		return s
+	}
+	c := color.New(attribute, color.Bold)
+	c.EnableColor()
+	return c.Sprint(s)
+}
+
+// caretIndent is the whitespace preceding a caret under the given column, keeping tabs so the caret lines up
+// however wide they're displayed.
+func caretIndent(sourceLine string, col int) string {
+	runes := []rune(sourceLine)
+	col = min(max(col, 1), len(runes)+1)
+	var sb strings.Builder
+	for _, r := range runes[:col-1] {
+		if r == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	return sb.String()
+}
+
+// shouldColor is true if the writer is a terminal, and colors have not been disabled.
+func shouldColor(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && !noColorFlag && os.Getenv("NO_COLOR") == "" && term.IsTerminal(int(f.Fd()))
+}
+
+// didYouMean suggests the candidate closest to the given name, if any is close enough to be a likely typo.
+// Returns an empty string otherwise.
+func didYouMean(name string, candidates []string) string {
+	sorted := append([]string{}, candidates...)
+	sort.Strings(sorted)
+
+	// short names are within a few edits of too much to suggest anything useful
+	maxDistance := len(name) / 3
+	best := ""
+	bestDistance := maxDistance + 1
+	for _, candidate := range sorted {
+		if candidate == name {
+			continue
+		}
+		distance := fuzzy.LevenshteinDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf("did you mean `%s`?", best)
+}
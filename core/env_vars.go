@@ -0,0 +1,64 @@
+package core
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvVars is the process environment, which scripts read with env() and set for child processes with set_env().
+type EnvVars interface {
+	Lookup(name string) (string, bool)
+	// Set makes the variable visible to any child processes run after this point.
+	Set(name string, value string) error
+	Names() []string
+}
+
+type RealEnvVars struct {
+}
+
+func NewRealEnvVars() EnvVars {
+	return &RealEnvVars{}
+}
+
+func (r *RealEnvVars) Lookup(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+func (r *RealEnvVars) Set(name string, value string) error {
+	return os.Setenv(name, value)
+}
+
+func (r *RealEnvVars) Names() []string {
+	var names []string
+	for _, entry := range os.Environ() {
+		name, _, _ := strings.Cut(entry, "=")
+		names = append(names, name)
+	}
+	return names
+}
+
+type FakeEnvVars struct {
+	Vars map[string]string
+}
+
+func NewFakeEnvVars() *FakeEnvVars {
+	return &FakeEnvVars{Vars: make(map[string]string)}
+}
+
+func (f *FakeEnvVars) Lookup(name string) (string, bool) {
+	value, ok := f.Vars[name]
+	return value, ok
+}
+
+func (f *FakeEnvVars) Set(name string, value string) error {
+	f.Vars[name] = value
+	return nil
+}
+
+func (f *FakeEnvVars) Names() []string {
+	var names []string
+	for name := range f.Vars {
+		names = append(names, name)
+	}
+	return names
+}
@@ -3,12 +3,14 @@ package core
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/samber/lo"
 )
 
 type Env struct {
 	i          *MainInterpreter
 	Vars       map[string]RuntimeLiteral
 	jsonFields map[string]JsonFieldVar
+	modules    map[string]*Env
 	Enclosing  *Env
 }
 
@@ -17,6 +19,7 @@ func NewEnv(i *MainInterpreter) *Env {
 		i:          i,
 		Vars:       make(map[string]RuntimeLiteral),
 		jsonFields: make(map[string]JsonFieldVar),
+		modules:    make(map[string]*Env),
 		Enclosing:  nil,
 	}
 }
@@ -26,21 +29,32 @@ func (e *Env) NewChildEnv() Env {
 		i:          e.i,
 		Vars:       make(map[string]RuntimeLiteral),
 		jsonFields: make(map[string]JsonFieldVar),
+		modules:    make(map[string]*Env),
 		Enclosing:  e,
 	}
 }
 
 func (e *Env) InitArg(arg CobraArg) {
 	if arg.IsNull {
+		// defined, so scripts can check it, but without a value
+		e.Vars[arg.Arg.Name] = NewRuntimeNull()
 		return
 	}
 
 	argType := arg.Arg.Type
 	switch argType {
 	case RslStringT:
-		e.Vars[arg.Arg.Name] = NewRuntimeString(arg.GetString())
+		if arg.Arg.IsSecret {
+			e.Vars[arg.Arg.Name] = NewRuntimeLiteral(NewRslSecret(arg.GetString()))
+		} else {
+			e.Vars[arg.Arg.Name] = NewRuntimeString(arg.GetString())
+		}
 	case RslStringArrayT:
-		e.Vars[arg.Arg.Name] = NewRuntimeStringArray(arg.GetStringArray())
+		if arg.Arg.IsSecret {
+			e.Vars[arg.Arg.Name] = NewRuntimeMixedArray(concealSecrets(arg.GetStringArray()).([]interface{}))
+		} else {
+			e.Vars[arg.Arg.Name] = NewRuntimeStringArray(arg.GetStringArray())
+		}
 	case RslIntT:
 		e.Vars[arg.Arg.Name] = NewRuntimeInt(arg.GetInt())
 	case RslIntArrayT:
@@ -55,6 +69,8 @@ func (e *Env) InitArg(arg CobraArg) {
 		e.Vars[arg.Arg.Name] = NewRuntimeBoolArray(arg.GetBoolArray())
 	case RslArrayT:
 		e.Vars[arg.Arg.Name] = NewRuntimeMixedArray(arg.GetMixedArray())
+	case RslDateT, RslDateTimeT, RslDurationT:
+		e.Vars[arg.Arg.Name] = NewRuntimeLiteral(arg.GetTimeValue())
 	default:
 		e.i.error(arg.Arg.DeclarationToken, fmt.Sprintf("Unsupported arg type, cannot init: %v", argType))
 	}
@@ -93,6 +109,10 @@ func (e *Env) SetAndImplyType(varNameToken Token, value interface{}) {
 	case []interface{}:
 		converted := e.recursivelyConvertTypes(varNameToken, value.([]interface{}))
 		e.Vars[varName] = NewRuntimeMixedArray(converted.([]interface{}))
+	case RslDate, RslDateTime, RslDuration, RslSecret, RslJsonObject:
+		e.Vars[varName] = NewRuntimeLiteral(value)
+	case nil:
+		e.Vars[varName] = NewRuntimeNull()
 	default:
 		e.i.error(varNameToken, fmt.Sprintf("Unknown type, cannot set: '%T' %q = %q", value, varName, value))
 	}
@@ -109,24 +129,37 @@ func (e *Env) SetAndExpectType(varNameToken Token, expectedType *RslTypeEnum, va
 		}
 	}
 
+	if value == nil {
+		// null is assignable to vars of any type
+		e.Vars[varName] = NewRuntimeNull()
+		return
+	}
+
 	if expectedType != nil {
 		expectedTypeVal := *expectedType
 		switch expectedTypeVal {
 		case RslStringT:
-			val, ok := value.(string)
-			if !ok {
+			switch value.(type) {
+			case string, RslSecret, RslJsonObject:
+				e.Vars[varName] = NewRuntimeLiteral(value)
+			default:
 				e.i.error(varNameToken, fmt.Sprintf("Type mismatch, expected string: %v", value))
-			} else {
-				e.Vars[varName] = NewRuntimeString(val)
 			}
 		case RslStringArrayT:
 			switch coerced := value.(type) {
 			case []string:
 				e.Vars[varName] = NewRuntimeStringArray(coerced)
 			case []interface{}:
-				strings, ok := AsStringArray(coerced)
-				if !ok {
+				text, hasObjects := jsonObjectsAsText(coerced)
+				strings, ok := AsStringArray(text.([]interface{}))
+				if hasSecrets(coerced) {
+					// kept as they are, so the secrets aren't revealed
+					e.Vars[varName] = NewRuntimeMixedArray(coerced)
+				} else if !ok {
 					e.i.error(varNameToken, fmt.Sprintf("Type mismatch, expected string array: %v", value))
+				} else if hasObjects {
+					// kept as they are, so to_json() still writes the objects out as objects
+					e.Vars[varName] = NewRuntimeMixedArray(coerced)
 				} else {
 					e.Vars[varName] = NewRuntimeStringArray(strings)
 				}
@@ -207,6 +240,12 @@ func (e *Env) SetAndExpectType(varNameToken Token, expectedType *RslTypeEnum, va
 			default:
 				e.i.error(varNameToken, fmt.Sprintf("Type mismatch, expected mixed array: %v", value))
 			}
+		case RslDateT, RslDateTimeT, RslDurationT:
+			literal := NewRuntimeLiteral(value)
+			if literal.Type != expectedTypeVal {
+				e.i.error(varNameToken, fmt.Sprintf("Type mismatch, expected %s: %v", expectedTypeVal.AsString(), value))
+			}
+			e.Vars[varName] = literal
 		default:
 			e.i.error(varNameToken, fmt.Sprintf("Unknown type, cannot set: %v = %v", varName, value))
 		}
@@ -251,19 +290,46 @@ func (e *Env) GetJsonField(name Token) JsonFieldVar {
 		if e.Enclosing != nil {
 			return e.Enclosing.GetJsonField(name)
 		}
-		e.i.error(name, fmt.Sprintf("Undefined json field referenced: %v", name))
+		e.i.errorWithHint(name, fmt.Sprintf("Undefined json field referenced: %v", name.GetLexeme()),
+			didYouMean(name.GetLexeme(), lo.Keys(e.jsonFields)))
 	}
 	return field
 }
 
+func (e *Env) SetModule(namespace string, moduleEnv *Env) {
+	e.modules[namespace] = moduleEnv
+}
+
+func (e *Env) GetModule(namespace Token) *Env {
+	module, ok := e.modules[namespace.GetLexeme()]
+	if !ok {
+		if e.Enclosing != nil {
+			return e.Enclosing.GetModule(namespace)
+		}
+		e.i.errorWithHint(namespace, fmt.Sprintf("Undefined module referenced: %v", namespace.GetLexeme()),
+			didYouMean(namespace.GetLexeme(), lo.Keys(e.modules)))
+	}
+	return module
+}
+
 func (e *Env) getOrError(varName string, varNameToken Token, acceptableTypes ...RslTypeEnum) RuntimeLiteral {
 	val, ok := e.get(varName, varNameToken, acceptableTypes...)
 	if !ok {
-		e.i.error(varNameToken, fmt.Sprintf("Undefined variable referenced: %v", varName))
+		e.i.errorWithHint(varNameToken, fmt.Sprintf("Undefined variable referenced: %v", varName),
+			didYouMean(varName, e.varNames()))
 	}
 	return val
 }
 
+// varNames returns the names of all variables visible from this env, for suggesting corrections to typos.
+func (e *Env) varNames() []string {
+	var names []string
+	for env := e; env != nil; env = env.Enclosing {
+		names = append(names, lo.Keys(env.Vars)...)
+	}
+	return lo.Uniq(names)
+}
+
 func (e *Env) get(varName string, varNameToken Token, acceptableTypes ...RslTypeEnum) (RuntimeLiteral, bool) {
 	val, ok := e.Vars[varName]
 	if !ok {
@@ -290,7 +356,7 @@ func (e *Env) recursivelyConvertTypes(token Token, arr interface{}) interface{}
 	switch coerced := arr.(type) {
 	// strictly speaking, I don't think ints are necessary to handle, since it seems Go unmarshalls
 	// json 'ints' into floats
-	case string, int64, float64, bool:
+	case string, int64, float64, bool, RslDate, RslDateTime, RslDuration, RslSecret, RslJsonObject:
 		return coerced
 	case int:
 		return int64(coerced)
@@ -305,10 +371,9 @@ func (e *Env) recursivelyConvertTypes(token Token, arr interface{}) interface{}
 		if err != nil {
 			e.i.error(token, fmt.Sprintf("Error marshalling json: %v", err))
 		}
-		return string(jsonData)
+		return RslJsonObject{text: string(jsonData)}
 	case nil:
-		// todo this is me wanting to avoid nulls in RSL.... but definitely surprising?
-		return "null"
+		return nil
 	default:
 		e.i.error(token, "Unsupported type in array")
 		panic(UNREACHABLE)
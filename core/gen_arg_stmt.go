@@ -11,14 +11,23 @@ type ArgStmt interface {
 }
 type ArgStmtVisitor interface {
 	VisitArgDeclarationArgStmt(ArgDeclaration)
+	VisitArgRequiresArgStmt(ArgRequires)
+	VisitArgOneOfArgStmt(ArgOneOf)
+	VisitArgCountArgStmt(ArgCount)
+	VisitArgRegexArgStmt(ArgRegex)
+	VisitArgRangeArgStmt(ArgRange)
 }
 type ArgDeclaration struct {
 	Identifier Token
 	Rename     *Token
 	Flag       *Token
 	ArgType    RslType
+	IsSecret   bool
 	IsOptional bool
 	Default    *LiteralOrArray
+	Enum       *StringArrayLiteral
+	EnvVar     *StringLiteralToken
+	Extensions *StringArrayLiteral
 	Comment    *ArgCommentToken
 }
 
@@ -31,8 +40,99 @@ func (e ArgDeclaration) String() string {
 	parts = append(parts, fmt.Sprintf("Rename: %v", e.Rename))
 	parts = append(parts, fmt.Sprintf("Flag: %v", e.Flag))
 	parts = append(parts, fmt.Sprintf("ArgType: %v", e.ArgType))
+	parts = append(parts, fmt.Sprintf("IsSecret: %v", e.IsSecret))
 	parts = append(parts, fmt.Sprintf("IsOptional: %v", e.IsOptional))
 	parts = append(parts, fmt.Sprintf("Default: %v", e.Default))
+	parts = append(parts, fmt.Sprintf("Enum: %v", e.Enum))
+	parts = append(parts, fmt.Sprintf("EnvVar: %v", e.EnvVar))
+	parts = append(parts, fmt.Sprintf("Extensions: %v", e.Extensions))
 	parts = append(parts, fmt.Sprintf("Comment: %v", e.Comment))
 	return fmt.Sprintf("ArgDeclaration(%s)", strings.Join(parts, ", "))
 }
+
+type ArgRequires struct {
+	Identifier    Token
+	RequiresToken Token
+	Required      []Token
+}
+
+func (e ArgRequires) Accept(visitor ArgStmtVisitor) {
+	visitor.VisitArgRequiresArgStmt(e)
+}
+func (e ArgRequires) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("Identifier: %v", e.Identifier))
+	parts = append(parts, fmt.Sprintf("RequiresToken: %v", e.RequiresToken))
+	parts = append(parts, fmt.Sprintf("Required: %v", e.Required))
+	return fmt.Sprintf("ArgRequires(%s)", strings.Join(parts, ", "))
+}
+
+type ArgOneOf struct {
+	OneOfToken  Token
+	Identifiers []Token
+}
+
+func (e ArgOneOf) Accept(visitor ArgStmtVisitor) {
+	visitor.VisitArgOneOfArgStmt(e)
+}
+func (e ArgOneOf) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("OneOfToken: %v", e.OneOfToken))
+	parts = append(parts, fmt.Sprintf("Identifiers: %v", e.Identifiers))
+	return fmt.Sprintf("ArgOneOf(%s)", strings.Join(parts, ", "))
+}
+
+type ArgCount struct {
+	CountToken  Token
+	Count       IntLiteralToken
+	Identifiers []Token
+}
+
+func (e ArgCount) Accept(visitor ArgStmtVisitor) {
+	visitor.VisitArgCountArgStmt(e)
+}
+func (e ArgCount) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("CountToken: %v", e.CountToken))
+	parts = append(parts, fmt.Sprintf("Count: %v", e.Count))
+	parts = append(parts, fmt.Sprintf("Identifiers: %v", e.Identifiers))
+	return fmt.Sprintf("ArgCount(%s)", strings.Join(parts, ", "))
+}
+
+type ArgRegex struct {
+	Identifiers []Token
+	Negated     bool
+	RegexToken  Token
+	Regex       StringLiteralToken
+}
+
+func (e ArgRegex) Accept(visitor ArgStmtVisitor) {
+	visitor.VisitArgRegexArgStmt(e)
+}
+func (e ArgRegex) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("Identifiers: %v", e.Identifiers))
+	parts = append(parts, fmt.Sprintf("Negated: %v", e.Negated))
+	parts = append(parts, fmt.Sprintf("RegexToken: %v", e.RegexToken))
+	parts = append(parts, fmt.Sprintf("Regex: %v", e.Regex))
+	return fmt.Sprintf("ArgRegex(%s)", strings.Join(parts, ", "))
+}
+
+type ArgRange struct {
+	Identifier Token
+	Comparator Token
+	Negative   bool
+	Bound      Token
+}
+
+func (e ArgRange) Accept(visitor ArgStmtVisitor) {
+	visitor.VisitArgRangeArgStmt(e)
+}
+func (e ArgRange) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("Identifier: %v", e.Identifier))
+	parts = append(parts, fmt.Sprintf("Comparator: %v", e.Comparator))
+	parts = append(parts, fmt.Sprintf("Negative: %v", e.Negative))
+	parts = append(parts, fmt.Sprintf("Bound: %v", e.Bound))
+	return fmt.Sprintf("ArgRange(%s)", strings.Join(parts, ", "))
+}
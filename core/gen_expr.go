@@ -20,6 +20,9 @@ type ExprVisitor interface {
 	VisitGroupingExpr(Grouping) interface{}
 	VisitUnaryExpr(Unary) interface{}
 	VisitListComprehensionExpr(ListComprehension) interface{}
+	VisitModuleAccessExpr(ModuleAccess) interface{}
+	VisitFallibleExpr(Fallible) interface{}
+	VisitNullCheckExpr(NullCheck) interface{}
 }
 type ExprLoa struct {
 	Value LiteralOrArray
@@ -67,6 +70,7 @@ func (e ArrayAccess) String() string {
 type FunctionCall struct {
 	Function                Token
 	Args                    []Expr
+	NamedArgs               []NamedArg
 	NumExpectedReturnValues int
 }
 
@@ -77,6 +81,7 @@ func (e FunctionCall) String() string {
 	var parts []string
 	parts = append(parts, fmt.Sprintf("Function: %v", e.Function))
 	parts = append(parts, fmt.Sprintf("Args: %v", e.Args))
+	parts = append(parts, fmt.Sprintf("NamedArgs: %v", e.NamedArgs))
 	parts = append(parts, fmt.Sprintf("NumExpectedReturnValues: %v", e.NumExpectedReturnValues))
 	return fmt.Sprintf("FunctionCall(%s)", strings.Join(parts, ", "))
 }
@@ -178,3 +183,52 @@ func (e ListComprehension) String() string {
 	parts = append(parts, fmt.Sprintf("Condition: %v", e.Condition))
 	return fmt.Sprintf("ListComprehension(%s)", strings.Join(parts, ", "))
 }
+
+type ModuleAccess struct {
+	Module Token
+	Name   Token
+}
+
+func (e ModuleAccess) Accept(visitor ExprVisitor) interface{} {
+	return visitor.VisitModuleAccessExpr(e)
+}
+func (e ModuleAccess) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("Module: %v", e.Module))
+	parts = append(parts, fmt.Sprintf("Name: %v", e.Name))
+	return fmt.Sprintf("ModuleAccess(%s)", strings.Join(parts, ", "))
+}
+
+type Fallible struct {
+	TryToken Token
+	Value    Expr
+	Fallback Expr
+}
+
+func (e Fallible) Accept(visitor ExprVisitor) interface{} {
+	return visitor.VisitFallibleExpr(e)
+}
+func (e Fallible) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("TryToken: %v", e.TryToken))
+	parts = append(parts, fmt.Sprintf("Value: %v", e.Value))
+	parts = append(parts, fmt.Sprintf("Fallback: %v", e.Fallback))
+	return fmt.Sprintf("Fallible(%s)", strings.Join(parts, ", "))
+}
+
+type NullCheck struct {
+	Value   Expr
+	IsToken Token
+	Negated bool
+}
+
+func (e NullCheck) Accept(visitor ExprVisitor) interface{} {
+	return visitor.VisitNullCheckExpr(e)
+}
+func (e NullCheck) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("Value: %v", e.Value))
+	parts = append(parts, fmt.Sprintf("IsToken: %v", e.IsToken))
+	parts = append(parts, fmt.Sprintf("Negated: %v", e.Negated))
+	return fmt.Sprintf("NullCheck(%s)", strings.Join(parts, ", "))
+}
@@ -14,9 +14,11 @@ type LiteralVisitor interface {
 	VisitIntLiteralLiteral(IntLiteral) interface{}
 	VisitFloatLiteralLiteral(FloatLiteral) interface{}
 	VisitBoolLiteralLiteral(BoolLiteral) interface{}
+	VisitNullLiteralLiteral(NullLiteral) interface{}
 }
 type StringLiteral struct {
-	Value StringLiteralToken
+	Value          StringLiteralToken
+	Interpolations []Interpolation
 }
 
 func (e StringLiteral) Accept(visitor LiteralVisitor) interface{} {
@@ -25,6 +27,7 @@ func (e StringLiteral) Accept(visitor LiteralVisitor) interface{} {
 func (e StringLiteral) String() string {
 	var parts []string
 	parts = append(parts, fmt.Sprintf("Value: %v", e.Value))
+	parts = append(parts, fmt.Sprintf("Interpolations: %v", e.Interpolations))
 	return fmt.Sprintf("StringLiteral(%s)", strings.Join(parts, ", "))
 }
 
@@ -66,3 +69,16 @@ func (e BoolLiteral) String() string {
 	parts = append(parts, fmt.Sprintf("Value: %v", e.Value))
 	return fmt.Sprintf("BoolLiteral(%s)", strings.Join(parts, ", "))
 }
+
+type NullLiteral struct {
+	Value Token
+}
+
+func (e NullLiteral) Accept(visitor LiteralVisitor) interface{} {
+	return visitor.VisitNullLiteralLiteral(e)
+}
+func (e NullLiteral) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("Value: %v", e.Value))
+	return fmt.Sprintf("NullLiteral(%s)", strings.Join(parts, ", "))
+}
@@ -25,8 +25,11 @@ type StmtVisitor interface {
 	VisitIfStmtStmt(IfStmt)
 	VisitIfCaseStmt(IfCase)
 	VisitForStmtStmt(ForStmt)
+	VisitWhileStmtStmt(WhileStmt)
 	VisitBreakStmtStmt(BreakStmt)
 	VisitContinueStmtStmt(ContinueStmt)
+	VisitImportStmtStmt(ImportStmt)
+	VisitTryStmtStmt(TryStmt)
 }
 type Empty struct {
 }
@@ -256,6 +259,23 @@ func (e ForStmt) String() string {
 	return fmt.Sprintf("ForStmt(%s)", strings.Join(parts, ", "))
 }
 
+type WhileStmt struct {
+	WhileToken Token
+	Condition  Expr
+	Body       Block
+}
+
+func (e WhileStmt) Accept(visitor StmtVisitor) {
+	visitor.VisitWhileStmtStmt(e)
+}
+func (e WhileStmt) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("WhileToken: %v", e.WhileToken))
+	parts = append(parts, fmt.Sprintf("Condition: %v", e.Condition))
+	parts = append(parts, fmt.Sprintf("Body: %v", e.Body))
+	return fmt.Sprintf("WhileStmt(%s)", strings.Join(parts, ", "))
+}
+
 type BreakStmt struct {
 	BreakToken Token
 }
@@ -281,3 +301,43 @@ func (e ContinueStmt) String() string {
 	parts = append(parts, fmt.Sprintf("ContinueToken: %v", e.ContinueToken))
 	return fmt.Sprintf("ContinueStmt(%s)", strings.Join(parts, ", "))
 }
+
+type ImportStmt struct {
+	ImportToken Token
+	Path        StringLiteral
+	Alias       *Token
+}
+
+func (e ImportStmt) Accept(visitor StmtVisitor) {
+	visitor.VisitImportStmtStmt(e)
+}
+func (e ImportStmt) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("ImportToken: %v", e.ImportToken))
+	parts = append(parts, fmt.Sprintf("Path: %v", e.Path))
+	parts = append(parts, fmt.Sprintf("Alias: %v", e.Alias))
+	return fmt.Sprintf("ImportStmt(%s)", strings.Join(parts, ", "))
+}
+
+type TryStmt struct {
+	TryToken      Token
+	TryBlock      Block
+	CatchToken    Token
+	ErrIdentifier *Token
+	LocIdentifier *Token
+	CatchBlock    Block
+}
+
+func (e TryStmt) Accept(visitor StmtVisitor) {
+	visitor.VisitTryStmtStmt(e)
+}
+func (e TryStmt) String() string {
+	var parts []string
+	parts = append(parts, fmt.Sprintf("TryToken: %v", e.TryToken))
+	parts = append(parts, fmt.Sprintf("TryBlock: %v", e.TryBlock))
+	parts = append(parts, fmt.Sprintf("CatchToken: %v", e.CatchToken))
+	parts = append(parts, fmt.Sprintf("ErrIdentifier: %v", e.ErrIdentifier))
+	parts = append(parts, fmt.Sprintf("LocIdentifier: %v", e.LocIdentifier))
+	parts = append(parts, fmt.Sprintf("CatchBlock: %v", e.CatchBlock))
+	return fmt.Sprintf("TryStmt(%s)", strings.Join(parts, ", "))
+}
@@ -20,10 +20,11 @@ func main() {
 
 	// literal -> STRING | NUMBER | BOOL
 	defineAst(outputDir, "Literal", "interface{}", []string{
-		"StringLiteral   : StringLiteralToken Value",
+		"StringLiteral   : StringLiteralToken Value, []Interpolation Interpolations",
 		"IntLiteral      : IntLiteralToken Value",
 		"FloatLiteral    : FloatLiteralToken Value",
 		"BoolLiteral     : BoolLiteralToken Value",
+		"NullLiteral     : Token Value",
 	})
 
 	// arrayLiteral -> "[" ( literal ( "," literal )* )? "]"
@@ -42,28 +43,35 @@ func main() {
 		"LoaArray     : ArrayLiteral Value",
 	})
 
-	// expression     -> logic_or
+	// expression     -> coalesce
+	// coalesce       -> logic_or ( "??" logic_or )*
 	// logic_or       -> logic_and ( "or" logic_and )*
 	// logic_and      -> equality ( "and" equality )*
-	// equality       -> comparison ( ( NOT_EQUAL | EQUAL ) comparison )*
+	// equality       -> comparison ( ( NOT_EQUAL | EQUAL ) comparison | "is" "not"? "null" )*
 	// comparison     -> term ( ( GT | GTE | LT | LTE ) term )*
 	// term           -> factor ( ( "-" | "+" ) factor )*
-	// factor         -> unary ( ( "/" | "*" ) unary )*
-	// unary          -> ( "!" | "-" ) unary | primary
-	// primary        -> "(" expression ")" | literalOrArray | arrayExpr | arrayAccess | functionCall | IDENTIFIER
+	// factor         -> unary ( ( "/" | "*" | "%" ) unary )*
+	// fallible       -> "try" logic_and "or" logic_and
+	// unary          -> ( "!" | "-" ) unary | power
+	// power          -> primary ( "**" unary )?
+	// primary        -> "(" expression ")" | literalOrArray | arrayExpr | arrayAccess | functionCall | moduleAccess | IDENTIFIER
 	// arrayAccess    -> IDENTIFIER "[" expression "]"
+	// moduleAccess   -> IDENTIFIER "." IDENTIFIER
 	// functionCall   -> IDENTIFIER "(" ( ( expression ( "," expression )* )? ( IDENTIFIER "=" expression ( "," IDENTIFIER "=" expression )* )? )? ")"
 	defineAst(outputDir, "Expr", "interface{}", []string{
 		"ExprLoa           : LiteralOrArray Value",
 		"ArrayExpr         : []Expr Values",
 		"ArrayAccess       : Expr Array, Expr Index, Token OpenBracketToken",
-		"FunctionCall      : Token Function, []Expr Args, int NumExpectedReturnValues", // todo named args
+		"FunctionCall      : Token Function, []Expr Args, []NamedArg NamedArgs, int NumExpectedReturnValues",
 		"Variable          : Token Name",
-		"Binary            : Expr Left, Token Operator, Expr Right", // +, -, *, /
-		"Logical           : Expr Left, Token Operator, Expr Right", // and, or
+		"Binary            : Expr Left, Token Operator, Expr Right", // +, -, *, /, %, **
+		"Logical           : Expr Left, Token Operator, Expr Right", // and, or, ??
 		"Grouping          : Expr Value",                            // ( expr )
 		"Unary             : Token Operator, Expr Right",            // !, -, +
 		"ListComprehension : Expr Expression, Token For, Token Identifier1, *Token Identifier2, Expr Range, *Expr Condition",
+		"ModuleAccess      : Token Module, Token Name",                  // common.base_url
+		"Fallible          : Token TryToken, Expr Value, Expr Fallback", // try value or fallback
+		"NullCheck         : Expr Value, Token IsToken, bool Negated",   // value is (not) null
 	})
 
 	defineAst(outputDir, "Stmt", "", []string{
@@ -82,13 +90,22 @@ func main() {
 		"IfStmt             : []IfCase Cases, *Block ElseBlock",
 		"IfCase             : Token IfToken, Expr Condition, Block Body",
 		"ForStmt			: Token ForToken, Token Identifier1, *Token Identifier2, Expr Range, Block Body",
+		"WhileStmt          : Token WhileToken, Expr Condition, Block Body",
 		"BreakStmt			: Token BreakToken",
 		"ContinueStmt		: Token ContinueToken",
+		"ImportStmt         : Token ImportToken, StringLiteral Path, *Token Alias",
+		"TryStmt            : Token TryToken, Block TryBlock, Token CatchToken, *Token ErrIdentifier, *Token LocIdentifier, Block CatchBlock",
 	})
 
 	defineAst(outputDir, "ArgStmt", "", []string{
-		"ArgDeclaration     : Token Identifier, *Token Rename, *Token Flag, RslType ArgType, " + // todo rename 'Rename'?
-			"bool IsOptional, *LiteralOrArray Default, *ArgCommentToken Comment",
+		"ArgDeclaration     : Token Identifier, *Token Rename, *Token Flag, RslType ArgType, bool IsSecret, " + // todo rename 'Rename'?
+			"bool IsOptional, *LiteralOrArray Default, *StringArrayLiteral Enum, *StringLiteralToken EnvVar, " +
+			"*StringArrayLiteral Extensions, *ArgCommentToken Comment",
+		"ArgRequires        : Token Identifier, Token RequiresToken, []Token Required",
+		"ArgOneOf           : Token OneOfToken, []Token Identifiers",
+		"ArgCount           : Token CountToken, IntLiteralToken Count, []Token Identifiers",
+		"ArgRegex           : []Token Identifiers, bool Negated, Token RegexToken, StringLiteralToken Regex",
+		"ArgRange           : Token Identifier, Token Comparator, bool Negative, Token Bound",
 	})
 
 	defineAst(outputDir, "RadStmt", "", []string{
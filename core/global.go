@@ -11,16 +11,20 @@ var (
 	RExit      func(int)
 	RReq       *Requester
 	RClock     Clock
+	REnvVars   EnvVars
+	RPicker    Picker
 	ScriptPath string
 	ScriptDir  string
 	ScriptName string
 )
 
 type CmdInput struct {
-	RIo    *RadIo
-	RExit  *func(int)
-	RReq   *Requester
-	RClock Clock
+	RIo      *RadIo
+	RExit    *func(int)
+	RReq     *Requester
+	RClock   Clock
+	REnvVars EnvVars
+	RPicker  Picker
 }
 
 func SetScriptPath(path string) {
@@ -36,6 +40,9 @@ func ResetGlobals() {
 	RExit = nil
 	RReq = nil
 	RClock = nil
+	REnvVars = nil
+	RPicker = nil
+	scriptedAnswers = nil
 }
 
 func setGlobals(cmdInput CmdInput) {
@@ -67,4 +74,16 @@ func setGlobals(cmdInput CmdInput) {
 		RClock = cmdInput.RClock
 
 	}
+
+	if cmdInput.REnvVars == nil {
+		REnvVars = NewRealEnvVars()
+	} else {
+		REnvVars = cmdInput.REnvVars
+	}
+
+	if cmdInput.RPicker == nil {
+		RPicker = NewHuhPicker()
+	} else {
+		RPicker = cmdInput.RPicker
+	}
 }
@@ -2,81 +2,341 @@ package core
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
 )
 
-// Helper function to process the string
-func processString(
-	s string,
-	callback func(capturing bool, escaped bool, char rune, variable string, result *strings.Builder, env *Env), env *Env,
-) string {
-	var result strings.Builder
-	capturing := false
-	escaped := false
-	variable := ""
-
-	for _, char := range s {
-		if char == '\\' {
-			if escaped {
-				result.WriteRune(char)
-				escaped = false
-			} else {
-				escaped = true
-			}
-			continue
+// Interpolation is a parsed {...} in a string literal, whose expression's value is written into the string.
+type Interpolation struct {
+	Expr   Expr
+	Format *InterpolationFormat // nil if there's no format
+	Token  Token                // the whole {...}
+}
+
+// InterpolationFormat is a format for an interpolated value, following Python's format spec, which is
+// [[fill]align][sign][0][width][grouping][.precision][type] e.g. {price:.2f}, {name:<20}, {count:,}.
+type InterpolationFormat struct {
+	Spec      string
+	Fill      rune
+	Align     rune // '<', '>', or '^', or 0 for the default, which is right for numbers and left for everything else
+	Sign      rune // '+' to show a sign on positive numbers too, ' ' to leave a space for one, or 0 for negatives only
+	ZeroPad   bool
+	Width     int
+	Grouping  rune // ',' or '_' between thousands, or 0 for none
+	Precision int  // -1 if not given
+	Type      rune // 0 if not given
+}
+
+const INTERPOLATION_FORMAT_HINT = "formats are [[fill]align][sign][0][width][,][.precision][type] " +
+	"e.g. `{price:>10,.2f}`, where type is one of s, d, f, e, %, x, X, o, b"
+
+// parseInterpolationFormat parses a format spec, returning an error message if it's invalid.
+func parseInterpolationFormat(spec string) (InterpolationFormat, string) {
+	format := InterpolationFormat{Spec: spec, Fill: ' ', Precision: -1}
+	invalid := fmt.Sprintf("Invalid format %q", spec)
+	runes := []rune(spec)
+	pos := 0
+	peekIs := func(chars string) bool {
+		return pos < len(runes) && strings.ContainsRune(chars, runes[pos])
+	}
+
+	if len(runes) >= 2 && strings.ContainsRune("<>^", runes[1]) {
+		format.Fill, format.Align = runes[0], runes[1]
+		pos = 2
+	} else if peekIs("<>^") {
+		format.Align = runes[0]
+		pos = 1
+	}
+	if peekIs("+- ") {
+		if runes[pos] != '-' {
+			format.Sign = runes[pos]
 		}
+		pos++
+	}
+	if peekIs("0") {
+		format.ZeroPad = true
+		pos++
+	}
+	for peekIs("0123456789") {
+		format.Width = format.Width*10 + int(runes[pos]-'0')
+		pos++
+	}
+	if peekIs(",_") {
+		format.Grouping = runes[pos]
+		pos++
+	}
+	if peekIs(".") {
+		pos++
+		if !peekIs("0123456789") {
+			return format, invalid + ": expected digits after '.'"
+		}
+		format.Precision = 0
+		for peekIs("0123456789") {
+			format.Precision = format.Precision*10 + int(runes[pos]-'0')
+			pos++
+		}
+	}
+	if peekIs("sdfeE%xXob") {
+		format.Type = runes[pos]
+		pos++
+	}
+	if pos != len(runes) {
+		return format, fmt.Sprintf("%s: unexpected %q", invalid, string(runes[pos:]))
+	}
 
-		if char == '{' {
-			if escaped {
-				result.WriteRune(char)
-				escaped = false
-			} else if !capturing {
-				capturing = true
-				variable = ""
-			} else {
-				variable += string(char)
-			}
-			continue
+	switch format.Type {
+	case 'd', 'x', 'X', 'o', 'b':
+		if format.Precision >= 0 {
+			return format, fmt.Sprintf("%s: '%c' doesn't take a precision", invalid, format.Type)
 		}
+	}
+	switch format.Type {
+	case 's', 'x', 'X', 'o', 'b':
+		if format.Grouping != 0 {
+			return format, fmt.Sprintf("%s: '%c' can't be grouped with '%c'", invalid, format.Type, format.Grouping)
+		}
+	}
+	if format.Type == 's' && (format.Sign != 0 || format.ZeroPad) {
+		return format, fmt.Sprintf("%s: 's' can't have a sign or zero padding", invalid)
+	}
+	return format, ""
+}
 
-		if char == '}' {
-			if escaped {
-				result.WriteRune(char)
-				escaped = false
-			} else if capturing {
-				capturing = false
-				if variable != "" {
-					callback(capturing, escaped, char, variable, &result, env)
-				}
-			} else {
-				result.WriteRune(char)
-			}
-			continue
+// expectedType is the type of value the format needs, or nil if it takes any.
+func (f InterpolationFormat) expectedType() []RslTypeEnum {
+	switch f.Type {
+	case 'd', 'x', 'X', 'o', 'b':
+		return []RslTypeEnum{RslIntT}
+	case 'f', 'e', 'E', '%':
+		return []RslTypeEnum{RslIntT, RslFloatT}
+	case 's':
+		return nil
+	}
+	if f.Sign != 0 || f.ZeroPad || f.Grouping != 0 {
+		return []RslTypeEnum{RslIntT, RslFloatT}
+	}
+	return nil
+}
+
+// mismatch returns an error message if the format can't be applied to the given type, or else "".
+func (f InterpolationFormat) mismatch(actual RslTypeEnum) string {
+	expected := f.expectedType()
+	if expected == nil || lo.Contains(expected, actual) {
+		return ""
+	}
+	return fmt.Sprintf("Format %q needs %s, got %s", f.Spec, describeTypes(expected),
+		describeTypes([]RslTypeEnum{actual}))
+}
+
+// apply formats the value, returning an error message if the value doesn't suit the format.
+func (f InterpolationFormat) apply(value interface{}) (string, string) {
+	if errMsg := f.mismatch(NewRuntimeLiteral(value).Type); errMsg != "" {
+		return "", errMsg
+	}
+
+	var formatted string
+	switch coerced := value.(type) {
+	case int64:
+		formatted = f.formatInt(coerced)
+	case float64:
+		formatted = f.formatFloat(coerced)
+	default:
+		formatted = ToPrintable(value)
+		if f.Precision >= 0 && utf8.RuneCountInString(formatted) > f.Precision {
+			formatted = string([]rune(formatted)[:f.Precision])
 		}
+	}
+	return f.pad(formatted, isNumberValue(value)), ""
+}
 
-		if capturing {
-			variable += string(char)
-		} else {
-			result.WriteRune(char)
+func (f InterpolationFormat) formatInt(value int64) string {
+	switch f.Type {
+	case 'f', 'e', 'E', '%':
+		return f.formatFloat(float64(value))
+	case 0, 'd':
+		if f.Precision >= 0 {
+			return f.formatFloat(float64(value))
 		}
 	}
 
-	return result.String()
+	magnitude := uint64(value)
+	if value < 0 {
+		magnitude = uint64(-value)
+	}
+	var digits string
+	switch f.Type {
+	case 'x':
+		digits = strconv.FormatUint(magnitude, 16)
+	case 'X':
+		digits = strings.ToUpper(strconv.FormatUint(magnitude, 16))
+	case 'o':
+		digits = strconv.FormatUint(magnitude, 8)
+	case 'b':
+		digits = strconv.FormatUint(magnitude, 2)
+	default:
+		digits = strconv.FormatUint(magnitude, 10)
+	}
+	return f.withSign(f.group(digits), value < 0)
 }
 
-// performStringInterpolation replaces {variables} in a string with their values
-func performStringInterpolation(s string, env *Env) string {
-	return processString(s, func(capturing bool, escaped bool, char rune, variable string, result *strings.Builder, env *Env) {
-		value := env.GetByName(variable).value
-		result.WriteString(fmt.Sprintf("%v", value))
-	}, env)
+func (f InterpolationFormat) formatFloat(value float64) string {
+	precision := f.Precision
+	if precision < 0 && f.Type != 0 {
+		precision = 6
+	}
+
+	magnitude := math.Abs(value)
+	var digits string
+	switch f.Type {
+	case 'e', 'E':
+		digits = strconv.FormatFloat(magnitude, byte(f.Type), precision, 64)
+	case '%':
+		digits = strconv.FormatFloat(magnitude*100, 'f', precision, 64) + "%"
+	default:
+		digits = strconv.FormatFloat(magnitude, 'f', precision, 64)
+	}
+	return f.withSign(f.group(digits), value < 0)
 }
 
-// extractVariables extracts variables within non-escaped {} brackets in the input string
-func extractVariables(s string) []string {
+// group separates the thousands in the leading whole number part of the digits.
+func (f InterpolationFormat) group(digits string) string {
+	if f.Grouping == 0 {
+		return digits
+	}
+	whole := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' })
+	if whole < 0 {
+		whole = len(digits)
+	}
+
+	var sb strings.Builder
+	for idx, char := range digits[:whole] {
+		if idx > 0 && (whole-idx)%3 == 0 {
+			sb.WriteRune(f.Grouping)
+		}
+		sb.WriteRune(char)
+	}
+	sb.WriteString(digits[whole:])
+	return sb.String()
+}
+
+func (f InterpolationFormat) withSign(digits string, negative bool) string {
+	sign := ""
+	if negative {
+		sign = "-"
+	} else if f.Sign != 0 {
+		sign = string(f.Sign)
+	}
+	if f.ZeroPad && f.Align == 0 {
+		// zeros go between the sign and the digits
+		if padding := f.Width - len(sign) - utf8.RuneCountInString(digits); padding > 0 {
+			digits = strings.Repeat("0", padding) + digits
+		}
+	}
+	return sign + digits
+}
+
+func (f InterpolationFormat) pad(formatted string, isNumber bool) string {
+	padding := f.Width - utf8.RuneCountInString(formatted)
+	if padding <= 0 {
+		return formatted
+	}
+
+	fill := string(f.Fill)
+	align := f.Align
+	if align == 0 {
+		align = lo.Ternary(isNumber, '>', '<')
+	}
+	switch align {
+	case '>':
+		return strings.Repeat(fill, padding) + formatted
+	case '^':
+		return strings.Repeat(fill, padding/2) + formatted + strings.Repeat(fill, padding-padding/2)
+	default:
+		return formatted + strings.Repeat(fill, padding)
+	}
+}
+
+// interpolate builds the string, evaluating and formatting its interpolations. The string is secret if any of
+// the interpolated values are.
+func (i *MainInterpreter) interpolate(literal StringLiteral) interface{} {
+	var parts []interface{}
+	idx := 0
+	for _, segment := range literal.Value.Segments {
+		if segment.Interpolation == nil {
+			parts = append(parts, segment.Text)
+			continue
+		}
+
+		interpolation := literal.Interpolations[idx]
+		idx++
+		value := interpolation.Expr.Accept(i)
+		parts = append(parts, i.formatInterpolated(interpolation, value))
+	}
+
+	if lo.SomeBy(parts, isSecret) {
+		return concatSecrets(parts...)
+	}
+	var sb strings.Builder
+	for _, part := range parts {
+		sb.WriteString(part.(string))
+	}
+	return sb.String()
+}
+
+// formatInterpolated formats the value, which is kept secret if it holds any secrets, with only they redacted.
+func (i *MainInterpreter) formatInterpolated(interpolation Interpolation, value interface{}) interface{} {
+	value = jsonObjectAsText(value)
+	if revealed, found := mapSecrets(value, func(secret RslSecret) interface{} { return secret.value }); found {
+		redacted := ToPrintable(value)
+		if interpolation.Format != nil {
+			redacted = interpolation.Format.pad(redacted, false)
+		}
+		return RslSecret{value: i.formatInterpolated(interpolation, revealed).(string), redacted: redacted}
+	}
+
+	if interpolation.Format == nil {
+		return ToPrintable(value)
+	}
+	formatted, errMsg := interpolation.Format.apply(value)
+	if errMsg != "" {
+		i.error(interpolation.Token, errMsg)
+	}
+	return formatted
+}
+
+// extractVariables returns the variables referenced by the string's interpolations, including repeats.
+func extractVariables(literal StringLiteral) []string {
 	var variables []string
-	processString(s, func(capturing bool, escaped bool, char rune, variable string, result *strings.Builder, env *Env) {
-		variables = append(variables, variable)
-	}, nil)
+	for _, segment := range literal.Value.Segments {
+		if segment.Interpolation == nil {
+			continue
+		}
+		tokens := segment.Interpolation.Expr
+		for idx, token := range tokens {
+			if _, isKeyword := GLOBAL_KEYWORDS[token.GetLexeme()]; token.GetType() != IDENTIFIER || isKeyword {
+				continue
+			}
+			isFunction := idx+1 < len(tokens) && tokens[idx+1].GetType() == LEFT_PAREN
+			isModuleMember := idx > 0 && tokens[idx-1].GetType() == DOT
+			if !isFunction && !isModuleMember {
+				variables = append(variables, token.GetLexeme())
+			}
+		}
+	}
 	return variables
 }
+
+// stringLiteralExpr returns the string literal which the expression is, if it is one.
+func stringLiteralExpr(expr Expr) (StringLiteral, bool) {
+	if loa, ok := expr.(*ExprLoa); ok {
+		if literal, ok := loa.Value.(*LoaLiteral); ok {
+			str, ok := literal.Value.(StringLiteral)
+			return str, ok
+		}
+	}
+	return StringLiteral{}, false
+}
@@ -8,6 +8,7 @@ type MainInterpreter struct {
 	argBlockI  *ArgBlockInterpreter
 	radBlockI  *RadBlockInterpreter
 	switchI    *SwitchInterpreter
+	modules    *moduleRegistry
 	statements []Stmt
 
 	breaking   bool
@@ -22,6 +23,7 @@ func NewInterpreter(statements []Stmt) *MainInterpreter {
 	i.argBlockI = NewArgBlockInterpreter(i)
 	i.radBlockI = NewRadBlockInterpreter(i)
 	i.switchI = NewSwitchInterpreter(i)
+	i.modules = newModuleRegistry()
 	i.env = NewEnv(i)
 	return i
 }
@@ -52,42 +54,97 @@ func (i *MainInterpreter) VisitArrayExprExpr(expr ArrayExpr) interface{} {
 }
 
 func (i *MainInterpreter) VisitArrayAccessExpr(access ArrayAccess) interface{} {
-	array := access.Array.Accept(i)
+	var array interface{}
+	if r, ok := i.evalRange(access.Array); ok {
+		// a directly indexed range() is kept lazy, so isn't made into an array
+		array = r
+	} else {
+		array = access.Array.Accept(i)
+	}
 	index := access.Index.Accept(i)
 
+	idx, ok := index.(int64)
+	if !ok {
+		i.error(access.OpenBracketToken, fmt.Sprintf("Array index must be an int, got %T", index))
+	}
+
 	switch coerced := array.(type) {
+	case intRange:
+		return coerced.at(i.checkIndex(access, idx, coerced.len()))
 	case []string:
-		return coerced[index.(int64)]
+		return coerced[i.checkIndex(access, idx, uint64(len(coerced)))]
 	case []int64:
-		return coerced[index.(int64)]
+		return coerced[i.checkIndex(access, idx, uint64(len(coerced)))]
 	case []float64:
-		return coerced[index.(int64)]
+		return coerced[i.checkIndex(access, idx, uint64(len(coerced)))]
 	case []bool:
-		return coerced[index.(int64)]
+		return coerced[i.checkIndex(access, idx, uint64(len(coerced)))]
 	case []interface{}:
-		return coerced[index.(int64)]
+		return coerced[i.checkIndex(access, idx, uint64(len(coerced)))]
 	default:
 		i.error(access.OpenBracketToken, "Bug! Should've failed earlier")
 		panic(UNREACHABLE)
 	}
 }
 
+func (i *MainInterpreter) checkIndex(access ArrayAccess, idx int64, length uint64) int64 {
+	if idx < 0 || uint64(idx) >= length {
+		i.error(access.OpenBracketToken, fmt.Sprintf("Array index out of bounds: %d (length %d)", idx, length))
+	}
+	return idx
+}
+
 func (i *MainInterpreter) VisitFunctionCallExpr(call FunctionCall) interface{} {
-	var args []interface{}
-	for _, v := range call.Args {
-		val := v.Accept(i)
-		args = append(args, val)
+	if length, ok := i.evalRangeLen(call); ok {
+		return length
 	}
-	return RunRslNonVoidFunction(i, call.Function, call.NumExpectedReturnValues, args)
+	return callWithSecrets(call.Function, i.evalArgs(call), func(args []interface{}) interface{} {
+		return RunRslNonVoidFunction(i, call.Function, call.NumExpectedReturnValues, args)
+	})
 }
 
 func (i *MainInterpreter) VisitFunctionStmtStmt(functionStmt FunctionStmt) {
+	callWithSecrets(functionStmt.Call.Function, i.evalArgs(functionStmt.Call), func(args []interface{}) interface{} {
+		RunRslFunction(i, functionStmt.Call.Function, args)
+		return nil
+	})
+}
+
+// evalArgs evaluates a call's args, and orders them by the function's params, named args included. Omitted optional
+// params are left off the end, or take their defaults if a later param is given.
+func (i *MainInterpreter) evalArgs(call FunctionCall) []interface{} {
 	var values []interface{}
-	for _, v := range functionStmt.Call.Args {
-		val := v.Accept(i)
-		values = append(values, val)
+	for _, v := range call.Args {
+		values = append(values, v.Accept(i))
+	}
+	for _, v := range call.NamedArgs {
+		values = append(values, v.Value.Accept(i))
 	}
-	RunRslFunction(i, functionStmt.Call.Function, values)
+
+	signature, ok := GetSignature(call.Function.GetLexeme())
+	if !ok {
+		// reported as unknown when run
+		return values
+	}
+
+	argTypes := make([]*RslTypeEnum, len(values))
+	for idx, value := range values {
+		argTypes[idx] = typeOf(NewRuntimeLiteral(value).Type)
+	}
+	slots, errs := bindArgs(call, signature, argTypes)
+	if len(errs) > 0 {
+		i.errorWithHint(errs[0].token, errs[0].msg, errs[0].hint)
+	}
+
+	args := make([]interface{}, len(slots))
+	for idx, slot := range slots {
+		if slot < 0 {
+			args[idx] = signature.Params[idx].Default
+		} else {
+			args[idx] = values[slot]
+		}
+	}
+	return args
 }
 
 func (i *MainInterpreter) VisitVariableExpr(variable Variable) interface{} {
@@ -95,14 +152,25 @@ func (i *MainInterpreter) VisitVariableExpr(variable Variable) interface{} {
 }
 
 func (i *MainInterpreter) VisitLogicalExpr(logical Logical) interface{} {
-	left := logical.Left.Accept(i).(bool)
-	right := logical.Right.Accept(i).(bool)
+	left := logical.Left.Accept(i)
+
+	operatorType := logical.Operator.GetType()
+	if keyword, ok := GLOBAL_KEYWORDS[logical.Operator.GetLexeme()]; ok {
+		// and/or are lexed as identifiers
+		operatorType = keyword
+	}
 
-	switch logical.Operator.GetType() {
+	// short-circuits, so e.g. 'a is not null and a > 2' is safe
+	switch operatorType {
 	case AND:
-		return left && right
+		return IsTruthy(left) && IsTruthy(logical.Right.Accept(i))
 	case OR:
-		return left || right
+		return IsTruthy(left) || IsTruthy(logical.Right.Accept(i))
+	case QUESTION_QUESTION:
+		if left != nil {
+			return left
+		}
+		return logical.Right.Accept(i)
 	default:
 		i.error(logical.Operator, "Bug! Non-and/or logical operator should've not passed the parser")
 		panic(UNREACHABLE)
@@ -113,6 +181,14 @@ func (i *MainInterpreter) VisitGroupingExpr(grouping Grouping) interface{} {
 	return grouping.Value.Accept(i)
 }
 
+func (i *MainInterpreter) VisitNullCheckExpr(check NullCheck) interface{} {
+	isNull := check.Value.Accept(i) == nil
+	if check.Negated {
+		return !isNull
+	}
+	return isNull
+}
+
 func (i *MainInterpreter) VisitUnaryExpr(unary Unary) interface{} {
 	value := unary.Right.Accept(i)
 
@@ -235,11 +311,7 @@ func (i *MainInterpreter) VisitIfStmtStmt(stmt IfStmt) {
 	cases := stmt.Cases
 	for _, c := range cases {
 		conditionResult := c.Condition.Accept(i)
-		bval, ok := conditionResult.(bool)
-		if !ok {
-			i.error(c.IfToken, "If condition must resolve to a bool")
-		}
-		if bval {
+		if IsTruthy(conditionResult) {
 			c.Body.Accept(i)
 			return
 		}
@@ -254,7 +326,7 @@ func (i *MainInterpreter) VisitIfCaseStmt(ifCase IfCase) {
 }
 
 func (i *MainInterpreter) VisitForStmtStmt(stmt ForStmt) {
-	rangeValue := stmt.Range.Accept(i)
+	rangeValue := i.evalIterable(stmt.Range)
 	var valIdentifier Token
 	var idxIdentifier *Token
 	if stmt.Identifier2 != nil {
@@ -280,13 +352,26 @@ func (i *MainInterpreter) VisitForStmtStmt(stmt ForStmt) {
 	case []interface{}:
 		arr := rangeValue.([]interface{})
 		i.runWithChildEnv(runForLoop(i, stmt, arr, idxIdentifier, valIdentifier))
+	case intRange:
+		i.runWithChildEnv(runRangeForLoop(i, stmt, rangeValue.(intRange), idxIdentifier, valIdentifier))
 	default:
 		i.error(stmt.ForToken, "For loop range must be an array")
 	}
 }
 
+func (i *MainInterpreter) VisitWhileStmtStmt(stmt WhileStmt) {
+	for IsTruthy(stmt.Condition.Accept(i)) {
+		stmt.Body.Accept(i)
+		if i.breaking {
+			i.breaking = false
+			break
+		}
+		i.continuing = false
+	}
+}
+
 func (i *MainInterpreter) VisitListComprehensionExpr(comp ListComprehension) interface{} {
-	rangeVals := comp.Range.Accept(i)
+	rangeVals := i.evalIterable(comp.Range)
 	var valIdent Token
 	var idxIdent *Token
 	if comp.Identifier2 != nil {
@@ -297,15 +382,17 @@ func (i *MainInterpreter) VisitListComprehensionExpr(comp ListComprehension) int
 	}
 	switch coerced := rangeVals.(type) {
 	case []string:
-		return i.computeWithChildEnv(runListComprehensionLoop(i, comp.For, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
+		return i.computeWithChildEnv(runListComprehensionLoop(i, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
 	case []int64:
-		return i.computeWithChildEnv(runListComprehensionLoop(i, comp.For, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
+		return i.computeWithChildEnv(runListComprehensionLoop(i, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
 	case []float64:
-		return i.computeWithChildEnv(runListComprehensionLoop(i, comp.For, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
+		return i.computeWithChildEnv(runListComprehensionLoop(i, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
 	case []bool:
-		return i.computeWithChildEnv(runListComprehensionLoop(i, comp.For, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
+		return i.computeWithChildEnv(runListComprehensionLoop(i, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
 	case []interface{}:
-		return i.computeWithChildEnv(runListComprehensionLoop(i, comp.For, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
+		return i.computeWithChildEnv(runListComprehensionLoop(i, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
+	case intRange:
+		return i.computeWithChildEnv(runRangeListComprehensionLoop(i, coerced, idxIdent, valIdent, comp.Expression, comp.Condition))
 	default:
 		i.error(comp.For, "List comprehension range must be an array")
 		panic(UNREACHABLE)
@@ -315,26 +402,42 @@ func (i *MainInterpreter) VisitListComprehensionExpr(comp ListComprehension) int
 func runForLoop[T any](i *MainInterpreter, stmt ForStmt, rangeArr []T, idxIdentifier *Token, valIdentifier Token) func() {
 	return func() {
 		for idx, val := range rangeArr {
-			i.env.SetAndImplyType(valIdentifier, val)
-			if idxIdentifier != nil {
-				i.env.SetAndImplyType(*idxIdentifier, int64(idx))
-			}
-			stmt.Body.Accept(i)
-			if i.breaking {
-				i.breaking = false
+			if !runForLoopIteration(i, stmt, int64(idx), val, idxIdentifier, valIdentifier) {
 				break
 			}
-			if i.continuing {
-				i.continuing = false
-				continue
+		}
+	}
+}
+
+func runRangeForLoop(i *MainInterpreter, stmt ForStmt, r intRange, idxIdentifier *Token, valIdentifier Token) func() {
+	return func() {
+		for idx := int64(0); uint64(idx) < r.len(); idx++ {
+			if !runForLoopIteration(i, stmt, idx, r.at(idx), idxIdentifier, valIdentifier) {
+				break
 			}
 		}
 	}
 }
 
+// runForLoopIteration returns false if the loop should stop
+func runForLoopIteration(i *MainInterpreter, stmt ForStmt, idx int64, val interface{}, idxIdentifier *Token, valIdentifier Token) bool {
+	i.env.SetAndImplyType(valIdentifier, val)
+	if idxIdentifier != nil {
+		i.env.SetAndImplyType(*idxIdentifier, idx)
+	}
+	stmt.Body.Accept(i)
+	if i.breaking {
+		i.breaking = false
+		return false
+	}
+	if i.continuing {
+		i.continuing = false
+	}
+	return true
+}
+
 func runListComprehensionLoop[T any](
 	i *MainInterpreter,
-	forToken Token,
 	rangeArr []T,
 	idxIdentifier *Token,
 	valIdentifier Token,
@@ -344,26 +447,49 @@ func runListComprehensionLoop[T any](
 	return func() interface{} {
 		var output []interface{}
 		for idx, val := range rangeArr {
-			i.env.SetAndImplyType(valIdentifier, val)
-			if idxIdentifier != nil {
-				i.env.SetAndImplyType(*idxIdentifier, int64(idx))
-			}
-			if condition != nil {
-				conditionResult := (*condition).Accept(i)
-				bval, ok := conditionResult.(bool)
-				if !ok {
-					i.error(forToken, "List comprehension condition must resolve to a bool")
-				}
-				if !bval {
-					continue
-				}
-			}
-			output = append(output, expression.Accept(i))
+			output = runListComprehensionIteration(i, output, int64(idx), val, idxIdentifier, valIdentifier, expression, condition)
 		}
 		return output
 	}
 }
 
+func runRangeListComprehensionLoop(
+	i *MainInterpreter,
+	r intRange,
+	idxIdentifier *Token,
+	valIdentifier Token,
+	expression Expr,
+	condition *Expr,
+) func() interface{} {
+	return func() interface{} {
+		var output []interface{}
+		for idx := int64(0); uint64(idx) < r.len(); idx++ {
+			output = runListComprehensionIteration(i, output, idx, r.at(idx), idxIdentifier, valIdentifier, expression, condition)
+		}
+		return output
+	}
+}
+
+func runListComprehensionIteration(
+	i *MainInterpreter,
+	output []interface{},
+	idx int64,
+	val interface{},
+	idxIdentifier *Token,
+	valIdentifier Token,
+	expression Expr,
+	condition *Expr,
+) []interface{} {
+	i.env.SetAndImplyType(valIdentifier, val)
+	if idxIdentifier != nil {
+		i.env.SetAndImplyType(*idxIdentifier, idx)
+	}
+	if condition != nil && !IsTruthy((*condition).Accept(i)) {
+		return output
+	}
+	return append(output, expression.Accept(i))
+}
+
 func (i *MainInterpreter) VisitBreakStmtStmt(stmt BreakStmt) {
 	i.breaking = true
 }
@@ -376,6 +502,11 @@ func (i *MainInterpreter) error(token Token, message string) {
 	RP.TokenErrorExit(token, message+"\n")
 }
 
+// errorWithHint is like error, with a suggestion for fixing it, if the hint is not empty.
+func (i *MainInterpreter) errorWithHint(token Token, message string, hint string) {
+	RP.RslErrorExit(RslError{Token: token, Msg: message, Hint: hint})
+}
+
 func (i *MainInterpreter) runWithChildEnv(runnable func()) {
 	originalEnv := i.env
 	env := originalEnv.NewChildEnv()
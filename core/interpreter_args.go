@@ -12,6 +12,23 @@ func (a ArgBlockInterpreter) VisitArgDeclarationArgStmt(declaration ArgDeclarati
 	// arg declarations already initialized in env, nothing to do on visit here, just pass
 }
 
+// constraints are checked before the script runs, so there's nothing to do for them here either
+
+func (a ArgBlockInterpreter) VisitArgRequiresArgStmt(ArgRequires) {
+}
+
+func (a ArgBlockInterpreter) VisitArgOneOfArgStmt(ArgOneOf) {
+}
+
+func (a ArgBlockInterpreter) VisitArgCountArgStmt(ArgCount) {
+}
+
+func (a ArgBlockInterpreter) VisitArgRegexArgStmt(ArgRegex) {
+}
+
+func (a ArgBlockInterpreter) VisitArgRangeArgStmt(ArgRange) {
+}
+
 func (a ArgBlockInterpreter) Run(block ArgBlock) {
 	for _, stmt := range block.Stmts {
 		stmt.Accept(a)
@@ -1,23 +1,20 @@
 package core
 
 type LiteralInterpreter struct {
-	i                 *MainInterpreter
-	ShouldInterpolate bool
+	i *MainInterpreter
 }
 
 func NewLiteralInterpreter(i *MainInterpreter) *LiteralInterpreter {
 	return &LiteralInterpreter{
-		i:                 i,
-		ShouldInterpolate: true,
+		i: i,
 	}
 }
 
 func (l LiteralInterpreter) VisitStringLiteralLiteral(literal StringLiteral) interface{} {
-	stringLiteral := literal.Value.Literal
-	if l.ShouldInterpolate && l.i != nil {
-		return performStringInterpolation(stringLiteral, l.i.env)
+	if l.i != nil {
+		return l.i.interpolate(literal)
 	}
-	return stringLiteral
+	return literal.Value.Literal
 }
 
 func (l LiteralInterpreter) VisitIntLiteralLiteral(literal IntLiteral) interface{} {
@@ -32,6 +29,10 @@ func (l LiteralInterpreter) VisitBoolLiteralLiteral(literal BoolLiteral) interfa
 	return literal.Value.Literal
 }
 
+func (l LiteralInterpreter) VisitNullLiteralLiteral(literal NullLiteral) interface{} {
+	return nil
+}
+
 func (l LiteralInterpreter) VisitStringArrayLiteralArrayLiteral(literal StringArrayLiteral) interface{} {
 	var values []string
 	for _, v := range literal.Values {
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 )
 
@@ -24,6 +25,8 @@ func (i *MainInterpreter) VisitCompoundAssignStmt(assign CompoundAssign) {
 		operatorType = STAR
 	case SLASH_EQUAL:
 		operatorType = SLASH
+	case PERCENT_EQUAL:
+		operatorType = PERCENT
 	default:
 		i.error(assign.Operator, "Invalid compound assignment operator")
 	}
@@ -32,6 +35,43 @@ func (i *MainInterpreter) VisitCompoundAssignStmt(assign CompoundAssign) {
 }
 
 func (i *MainInterpreter) execute(left interface{}, right interface{}, operatorToken Token, operatorType TokenType) interface{} {
+	if left == nil || right == nil {
+		switch operatorType {
+		case EQUAL_EQUAL:
+			return left == right
+		case NOT_EQUAL:
+			return left != right
+		default:
+			i.error(operatorToken, fmt.Sprintf("Cannot apply '%s' to null", operatorToken.GetLexeme()))
+		}
+	}
+
+	// objects read from JSON are operated on as the strings they are to scripts
+	left, right = jsonObjectAsText(left), jsonObjectAsText(right)
+
+	if isSecret(left) || isSecret(right) {
+		return i.executeSecret(left, right, operatorToken, operatorType)
+	}
+
+	if isTimeValue(left) || isTimeValue(right) {
+		return i.executeTime(left, right, operatorToken, operatorType)
+	}
+
+	if isNumberValue(left) && isNumberValue(right) {
+		switch operatorType {
+		case SLASH:
+			if isZero(right) {
+				i.error(operatorToken, "Division by zero")
+			}
+		case PERCENT:
+			if isZero(right) {
+				i.error(operatorToken, "Modulo by zero")
+			}
+		case STAR_STAR:
+			return power(i, operatorToken, left, right)
+		}
+	}
+
 	switch left.(type) {
 	case int64:
 		switch right.(type) {
@@ -45,6 +85,8 @@ func (i *MainInterpreter) execute(left interface{}, right interface{}, operatorT
 				return left.(int64) * right.(int64)
 			case SLASH:
 				return left.(int64) / right.(int64)
+			case PERCENT:
+				return left.(int64) % right.(int64)
 			case GREATER:
 				return left.(int64) > right.(int64)
 			case GREATER_EQUAL:
@@ -70,6 +112,8 @@ func (i *MainInterpreter) execute(left interface{}, right interface{}, operatorT
 				return float64(left.(int64)) * right.(float64)
 			case SLASH:
 				return float64(left.(int64)) / right.(float64)
+			case PERCENT:
+				return math.Mod(float64(left.(int64)), right.(float64))
 			case GREATER:
 				return float64(left.(int64)) > right.(float64)
 			case GREATER_EQUAL:
@@ -100,6 +144,8 @@ func (i *MainInterpreter) execute(left interface{}, right interface{}, operatorT
 				return left.(float64) * float64(right.(int64))
 			case SLASH:
 				return left.(float64) / float64(right.(int64))
+			case PERCENT:
+				return math.Mod(left.(float64), float64(right.(int64)))
 			case GREATER:
 				return left.(float64) > float64(right.(int64))
 			case GREATER_EQUAL:
@@ -125,6 +171,8 @@ func (i *MainInterpreter) execute(left interface{}, right interface{}, operatorT
 				return left.(float64) * right.(float64)
 			case SLASH:
 				return left.(float64) / right.(float64)
+			case PERCENT:
+				return math.Mod(left.(float64), right.(float64))
 			case GREATER:
 				return left.(float64) > right.(float64)
 			case GREATER_EQUAL:
@@ -404,3 +452,35 @@ func (i *MainInterpreter) execute(left interface{}, right interface{}, operatorT
 	}
 	panic(UNREACHABLE)
 }
+
+func isNumberValue(value interface{}) bool {
+	switch value.(type) {
+	case int64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isZero(value interface{}) bool {
+	switch coerced := value.(type) {
+	case int64:
+		return coerced == 0
+	case float64:
+		return coerced == 0
+	default:
+		return false
+	}
+}
+
+// executeSecret applies the operator to values, at least one of which is a secret. Strings made from secrets are
+// secret too.
+func (i *MainInterpreter) executeSecret(left interface{}, right interface{}, operatorToken Token, operatorType TokenType) interface{} {
+	revealed, _ := revealSecrets([]interface{}{left, right})
+	result := i.execute(revealed[0], revealed[1], operatorToken, operatorType)
+	if _, isString := result.(string); isString && operatorType == PLUS {
+		// joined, so only the secret parts need be redacted
+		return concatSecrets(left, right)
+	}
+	return concealSecrets(result)
+}
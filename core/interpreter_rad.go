@@ -18,12 +18,12 @@ func NewRadBlockInterpreter(i *MainInterpreter) *RadBlockInterpreter {
 }
 
 func (r RadBlockInterpreter) Run(block RadBlock) {
-	var url *string
+	var url interface{}
 	if block.Source != nil {
 		src := (*block.Source).Accept(r.i) // todo might be a json blob, in the future
-		switch coerced := src.(type) {
-		case string:
-			url = &coerced
+		switch src.(type) {
+		case string, RslSecret:
+			url = src
 		default:
 			r.i.error(block.RadKeyword, "URL must be a string")
 		}
@@ -94,7 +94,7 @@ func (r RadBlockInterpreter) VisitSortRadStmt(sort Sort) {
 type radInvocation struct {
 	ri               *RadBlockInterpreter
 	block            RadBlock
-	url              *string
+	url              interface{} // a string or secret, or nil if there's no source
 	fields           Fields
 	fieldsToNotPrint *strset.Set
 	sorting          []ColumnSort
@@ -121,7 +121,7 @@ func (r *radInvocation) execute() {
 			return r.ri.i.env.GetJsonField(field)
 		})
 
-		data, err := RReq.RequestJson(*r.url)
+		data, err := RReq.RequestJson(r.url)
 		if err != nil {
 			r.error(fmt.Sprintf("Error requesting JSON: %v", err))
 		}
@@ -175,7 +175,7 @@ func executeRequestPassthrough(r *radInvocation) {
 	}
 
 	// execute request, don't expect responses, just print out the response body
-	data, err := RReq.Request(*url)
+	data, err := RReq.Request(url)
 	if err != nil {
 		r.error(fmt.Sprintf("Error requesting: %v", err))
 	}
@@ -100,7 +100,8 @@ func (s *switchInvocation) execute() []RuntimeLiteral {
 
 func (s *switchInvocation) decideBasedOnKeys() []RuntimeLiteral {
 	discrValueLiteral := s.si.i.env.GetByToken(s.discriminator)
-	discrValueString := fmt.Sprintf("%v", discrValueLiteral.value)
+	discrValue, _ := mapSecrets(discrValueLiteral.value, func(secret RslSecret) interface{} { return secret.value })
+	discrValueString := fmt.Sprintf("%v", discrValue)
 
 	var exprs []Expr
 	for _, instance := range s.cases {
@@ -130,8 +131,6 @@ func (s *switchInvocation) decideBasedOnKeys() []RuntimeLiteral {
 // 5. if there is still a tie, error
 
 func (s *switchInvocation) decideBasedOnStringInterpolation() []RuntimeLiteral {
-	s.si.i.LiteralI.ShouldInterpolate = false
-
 	// count unique and total variable counts for every case
 	var numTotalReferencedVarsByCaseIndex []int
 	var numUniqueReferencedVarsByCaseIndex []int
@@ -139,9 +138,8 @@ func (s *switchInvocation) decideBasedOnStringInterpolation() []RuntimeLiteral {
 		var numTotalForCase int = 0
 		uniqueReferencedVars := strset.New()
 		for _, expr := range instance.values {
-			value, ok := expr.Accept(s.si.i).(string)
-			if ok {
-				referencedVars := extractVariables(value)
+			if literal, ok := stringLiteralExpr(expr); ok {
+				referencedVars := extractVariables(literal)
 				numTotalForCase += len(referencedVars)
 				uniqueReferencedVars.Add(referencedVars...)
 			}
@@ -187,8 +185,6 @@ func (s *switchInvocation) decideBasedOnStringInterpolation() []RuntimeLiteral {
 		s.si.i.error(s.blockToken, "No choice block option matches all its referenced variables")
 	}
 
-	s.si.i.LiteralI.ShouldInterpolate = true
-
 	var outputs []RuntimeLiteral
 	for _, expr := range s.cases[highestCaseIndex].values {
 		// we end up re-evaluating the winning expressions, but in theory they should be idempotent?
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// executeTime applies a binary operator where either operand is a date, datetime, or duration.
+// Dates and datetimes may be offset by durations, and subtracted from each other to get the duration between them.
+// Durations may be added, subtracted, scaled by numbers, and divided by each other.
+func (i *MainInterpreter) executeTime(left interface{}, right interface{}, operatorToken Token, operatorType TokenType) interface{} {
+	if operatorType == PLUS {
+		if l, ok := left.(string); ok {
+			return l + ToPrintable(right)
+		}
+		if r, ok := right.(string); ok {
+			return ToPrintable(left) + r
+		}
+	}
+
+	switch l := left.(type) {
+	case RslDate, RslDateTime:
+		lTime := asTime(l)
+		switch r := right.(type) {
+		case RslDuration:
+			switch operatorType {
+			case PLUS, MINUS:
+				if operatorType == MINUS {
+					r = -r
+				}
+				if date, ok := l.(RslDate); ok {
+					return addToDate(date, r)
+				}
+				return RslDateTime{Time: lTime.Add(time.Duration(r))}
+			}
+		case RslDate, RslDateTime:
+			if operatorType == MINUS {
+				return RslDuration(lTime.Sub(asTime(r)))
+			}
+			if result, ok := compare(operatorType, lTime.Compare(asTime(r))); ok {
+				return result
+			}
+		}
+	case RslDuration:
+		switch r := right.(type) {
+		case RslDuration:
+			switch operatorType {
+			case PLUS:
+				return l + r
+			case MINUS:
+				return l - r
+			case SLASH:
+				if r == 0 {
+					i.error(operatorToken, "Cannot divide by a zero duration")
+				}
+				return float64(l) / float64(r)
+			}
+			if result, ok := compare(operatorType, int(l-r)); ok {
+				return result
+			}
+		case RslDate:
+			if operatorType == PLUS {
+				return addToDate(r, l)
+			}
+		case RslDateTime:
+			if operatorType == PLUS {
+				return RslDateTime{Time: r.Time.Add(time.Duration(l))}
+			}
+		case int64:
+			return i.scaleDuration(l, float64(r), operatorToken, operatorType)
+		case float64:
+			return i.scaleDuration(l, r, operatorToken, operatorType)
+		}
+	case int64:
+		if r, ok := right.(RslDuration); ok && operatorType == STAR {
+			return RslDuration(float64(r) * float64(l))
+		}
+	case float64:
+		if r, ok := right.(RslDuration); ok && operatorType == STAR {
+			return RslDuration(float64(r) * l)
+		}
+	}
+
+	if operatorType == EQUAL_EQUAL || operatorType == NOT_EQUAL {
+		// values of different types are never equal
+		return operatorType == NOT_EQUAL
+	}
+	i.error(operatorToken, fmt.Sprintf("Invalid binary operator '%s' for %s, %s",
+		operatorToken.GetLexeme(), NewRuntimeLiteral(left).Type.AsString(), NewRuntimeLiteral(right).Type.AsString()))
+	panic(UNREACHABLE)
+}
+
+func (i *MainInterpreter) scaleDuration(duration RslDuration, factor float64, operatorToken Token, operatorType TokenType) interface{} {
+	switch operatorType {
+	case STAR:
+		return RslDuration(float64(duration) * factor)
+	case SLASH:
+		if factor == 0 {
+			i.error(operatorToken, "Cannot divide by zero")
+		}
+		return RslDuration(float64(duration) / factor)
+	default:
+		i.error(operatorToken, fmt.Sprintf("Invalid binary operator '%s' for duration, number", operatorToken.GetLexeme()))
+		panic(UNREACHABLE)
+	}
+}
+
+func asTime(value interface{}) time.Time {
+	if date, ok := value.(RslDate); ok {
+		return date.Time
+	}
+	return value.(RslDateTime).Time
+}
+
+// compare applies a comparison operator to the result of comparing two values, as returned by e.g. time.Compare.
+// Returns false if the operator isn't a comparison.
+func compare(operatorType TokenType, comparison int) (bool, bool) {
+	switch operatorType {
+	case GREATER:
+		return comparison > 0, true
+	case GREATER_EQUAL:
+		return comparison >= 0, true
+	case LESS:
+		return comparison < 0, true
+	case LESS_EQUAL:
+		return comparison <= 0, true
+	case EQUAL_EQUAL:
+		return comparison == 0, true
+	case NOT_EQUAL:
+		return comparison != 0, true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RslError is an error raised while running RSL, captured rather than exiting so that try/catch blocks and
+// fallible expressions can recover from it.
+type RslError struct {
+	// nil if the error was raised without a token for context
+	Token Token
+	Msg   string
+	// optional extra context, printed after the excerpt of the source
+	Note string
+	// optional suggestion for fixing the error e.g. a correction for a typo
+	Hint string
+}
+
+func (e *RslError) Error() string {
+	return e.Msg
+}
+
+// capturingPrinter turns RSL errors into *RslError panics, which are recovered by runCapturingErrors.
+// Rad errors (bugs, usage errors) are not capturable and still exit via the wrapped printer.
+type capturingPrinter struct {
+	Printer
+}
+
+func (p *capturingPrinter) ErrorExit(msg string) {
+	panic(&RslError{Msg: strings.TrimSuffix(msg, "\n")})
+}
+
+func (p *capturingPrinter) TokenErrorExit(token Token, msg string) {
+	panic(&RslError{Token: token, Msg: strings.TrimSuffix(msg, "\n")})
+}
+
+func (p *capturingPrinter) RslErrorExit(err RslError) {
+	panic(&err)
+}
+
+func (p *capturingPrinter) TokenErrorsExit(errs []RslError) {
+	panic(&errs[0])
+}
+
+// runCapturingErrors runs the given function, returning the RSL error it raised, if any.
+func (i *MainInterpreter) runCapturingErrors(runnable func()) (rslErr *RslError) {
+	originalPrinter := RP
+	originalEnv := i.env
+	RP = &capturingPrinter{Printer: originalPrinter}
+	defer func() {
+		RP = originalPrinter
+		if r := recover(); r != nil {
+			captured, ok := r.(*RslError)
+			if !ok {
+				panic(r)
+			}
+			// the error may have interrupted a nested block before it could restore its env
+			i.env = originalEnv
+			i.breaking = false
+			i.continuing = false
+			rslErr = captured
+		}
+	}()
+	runnable()
+	return nil
+}
+
+func (i *MainInterpreter) VisitTryStmtStmt(stmt TryStmt) {
+	rslErr := i.runCapturingErrors(func() {
+		stmt.TryBlock.Accept(i)
+	})
+	if rslErr == nil {
+		return
+	}
+
+	i.runWithChildEnv(func() {
+		if stmt.ErrIdentifier != nil {
+			i.env.SetAndImplyType(*stmt.ErrIdentifier, rslErr.Msg)
+		}
+		if stmt.LocIdentifier != nil {
+			locToken := rslErr.Token
+			if locToken == nil {
+				locToken = stmt.TryToken
+			}
+			loc := fmt.Sprintf("L%d/%d", locToken.GetLine(), locToken.GetCharLineStart())
+			i.env.SetAndImplyType(*stmt.LocIdentifier, loc)
+		}
+		stmt.CatchBlock.Accept(i)
+	})
+}
+
+func (i *MainInterpreter) VisitFallibleExpr(fallible Fallible) interface{} {
+	var value interface{}
+	rslErr := i.runCapturingErrors(func() {
+		value = fallible.Value.Accept(i)
+	})
+	if rslErr != nil {
+		return fallible.Fallback.Accept(i)
+	}
+	return value
+}
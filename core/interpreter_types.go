@@ -9,6 +9,9 @@ func NewRuntimeLiteral(val interface{}) RuntimeLiteral {
 	switch val.(type) {
 	case string:
 		return NewRuntimeString(val.(string))
+	case RslSecret, RslJsonObject:
+		// a string, as far as scripts can tell
+		return RuntimeLiteral{Type: RslStringT, value: val}
 	case []string:
 		return NewRuntimeStringArray(val.([]string))
 	case int64:
@@ -25,6 +28,14 @@ func NewRuntimeLiteral(val interface{}) RuntimeLiteral {
 		return NewRuntimeBoolArray(val.([]bool))
 	case []interface{}:
 		return NewRuntimeMixedArray(val.([]interface{}))
+	case RslDate:
+		return NewRuntimeDate(val.(RslDate))
+	case RslDateTime:
+		return NewRuntimeDateTime(val.(RslDateTime))
+	case RslDuration:
+		return NewRuntimeDuration(val.(RslDuration))
+	case nil:
+		return NewRuntimeNull()
 	default:
 		// todo via printer
 		panic("unknown type")
@@ -67,6 +78,22 @@ func NewRuntimeMixedArray(val []interface{}) RuntimeLiteral {
 	return RuntimeLiteral{Type: RslArrayT, value: val}
 }
 
+func NewRuntimeDate(val RslDate) RuntimeLiteral {
+	return RuntimeLiteral{Type: RslDateT, value: val}
+}
+
+func NewRuntimeDateTime(val RslDateTime) RuntimeLiteral {
+	return RuntimeLiteral{Type: RslDateTimeT, value: val}
+}
+
+func NewRuntimeDuration(val RslDuration) RuntimeLiteral {
+	return RuntimeLiteral{Type: RslDurationT, value: val}
+}
+
+func NewRuntimeNull() RuntimeLiteral {
+	return RuntimeLiteral{Type: RslNullT, value: nil}
+}
+
 func (l RuntimeLiteral) GetString() string {
 	return l.value.(string)
 }
@@ -138,7 +138,7 @@ func (t *Trie) traverse(data interface{}, node *Node, keyToCaptureInstead interf
 			}
 			// max: we want to capture at least once, but if we've captured from children nodes, we want to capture
 			// that many
-			t.capture(string(jsonData), node, keyToCaptureInstead, max(capStats.captures, 1))
+			t.capture(RslJsonObject{text: string(jsonData)}, node, keyToCaptureInstead, max(capStats.captures, 1))
 		}
 	default:
 		RP.TokenErrorExit(node.radToken, fmt.Sprintf("Expected map for non-array node '%v': %v\n", node, data))
@@ -2,6 +2,7 @@ package core
 
 var GLOBAL_KEYWORDS = map[string]TokenType{
 	"for":      FOR,
+	"while":    WHILE,
 	"break":    BREAK,
 	"continue": CONTINUE,
 	"in":       IN,
@@ -17,6 +18,12 @@ var GLOBAL_KEYWORDS = map[string]TokenType{
 	"if":       IF,
 	"else":     ELSE,
 	"resource": RESOURCE,
+	"import":   IMPORT,
+	"as":       AS,
+	"try":      TRY,
+	"catch":    CATCH,
+	"is":       IS,
+	"not":      NOT,
 }
 
 var ARGS_BLOCK_KEYWORDS = map[string]TokenType{
@@ -28,6 +35,16 @@ var ARGS_BLOCK_KEYWORDS = map[string]TokenType{
 	"requires": REQUIRES,
 	"one_of":   ONE_OF,
 	"regex":    REGEX,
+	"at_least": AT_LEAST,
+	"exactly":  EXACTLY,
+	"at_most":  AT_MOST,
+	"env":      ENV,
+	"secret":   SECRET,
+	"date":     DATE,
+	"datetime": DATETIME,
+	"duration": DURATION,
+	"path":     PATH,
+	"dir":      DIR,
 }
 
 var RAD_BLOCK_KEYWORDS = map[string]TokenType{
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 type Lexer struct {
@@ -91,6 +92,8 @@ func (l *Lexer) scanToken() {
 		l.addToken(COMMA)
 	case ':':
 		l.addToken(COLON)
+	case '.':
+		l.addToken(DOT)
 	case '\n':
 		l.addToken(NEWLINE)
 	case '=':
@@ -106,7 +109,11 @@ func (l *Lexer) scanToken() {
 			l.addToken(EXCLAMATION)
 		}
 	case '?':
-		l.addToken(QUESTION)
+		if l.match('?') {
+			l.addToken(QUESTION_QUESTION)
+		} else {
+			l.addToken(QUESTION)
+		}
 	case '<':
 		if l.match('=') {
 			l.addToken(LESS_EQUAL)
@@ -147,10 +154,14 @@ func (l *Lexer) scanToken() {
 		} else {
 			l.lexArgComment()
 		}
-	case '"':
-		l.lexStringLiteral('"')
-	case '\'':
-		l.lexStringLiteral('\'')
+	case '"', '\'':
+		l.lexStringLiteral(c, false)
+	case 'r':
+		if l.peek() == '"' || l.peek() == '\'' {
+			l.lexStringLiteral(l.advance(), true)
+		} else {
+			l.lexIdentifier()
+		}
 	case 'j':
 		if l.matchString("son") {
 			l.lexJsonPath()
@@ -168,11 +179,19 @@ func (l *Lexer) scanToken() {
 			l.addToken(SLASH)
 		}
 	case '*':
-		if l.match('=') {
+		if l.match('*') {
+			l.addToken(STAR_STAR)
+		} else if l.match('=') {
 			l.addToken(STAR_EQUAL)
 		} else {
 			l.addToken(STAR)
 		}
+	case '%':
+		if l.match('=') {
+			l.addToken(PERCENT_EQUAL)
+		} else {
+			l.addToken(PERCENT)
+		}
 	case ' ', '\t':
 		// ignore whitespace if not at start of line
 	default:
@@ -227,12 +246,13 @@ func (l *Lexer) matchAny(expected ...rune) bool {
 }
 
 func (l *Lexer) matchString(expected string) bool {
-	for i, c := range expected {
-		if l.next+i >= len(l.source) || rune(l.source[l.next+i]) != c {
-			return false
-		}
+	if !l.peekEquals(expected) {
+		return false
+	}
+	// advanced over one at a time, so lines and columns are tracked across any newlines
+	for range expected {
+		l.advance()
 	}
-	l.next += len(expected)
 	return true
 }
 
@@ -266,8 +286,41 @@ func (l *Lexer) expectNoEmit(expected rune, errorMessage string) {
 	}
 }
 
+// rewind steps back over characters on the current line
 func (l *Lexer) rewind(num int) {
 	l.next -= num
+	l.lineCharIndex -= num
+}
+
+func isBlank(s string) bool {
+	return strings.TrimLeft(s, " \t\r") == ""
+}
+
+// commonIndent is the fewest spaces or tabs that any non-blank line in the text starts with.
+func commonIndent(text string) int {
+	indent := -1
+	for _, line := range strings.Split(text, "\n") {
+		if isBlank(line) {
+			continue
+		}
+		lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || lineIndent < indent {
+			indent = lineIndent
+		}
+	}
+	return max(indent, 0)
+}
+
+// dedent removes up to the given number of spaces or tabs from the start of each line in the text.
+func dedent(text string, indent int) string {
+	if indent == 0 {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		lines[idx] = line[min(indent, len(line)-len(strings.TrimLeft(line, " \t"))):]
+	}
+	return strings.Join(lines, "\n")
 }
 
 func isAlpha(c rune) bool {
@@ -278,15 +331,160 @@ func isDigit(c rune) bool {
 	return c >= '0' && c <= '9'
 }
 
-func (l *Lexer) lexStringLiteral(endChar rune) {
-	value := ""
-	for !l.match(endChar) {
-		if l.isAtEnd() {
-			l.error("Unterminated string")
+// lexStringLiteral lexes a string after its opening quote. Strings in triple quotes may span lines, and have the
+// indentation common to their lines removed, along with the line breaks after the opening and before the closing
+// quotes, if those are on lines of their own. Raw strings, prefixed with 'r', keep backslashes and braces as written.
+func (l *Lexer) lexStringLiteral(quoteChar rune, raw bool) {
+	quote := string(quoteChar)
+	if l.peekEquals(quote + quote) {
+		l.advance()
+		l.advance()
+		quote = strings.Repeat(quote, 3)
+	}
+	isMultiline := len(quote) == 3
+
+	closeOffset := strings.Index(l.source[l.next:], quote)
+	if closeOffset < 0 {
+		// pointed at the opening quote, as the rest of the script is taken to be in the string
+		l.error("Unterminated string")
+	}
+	end := l.next + closeOffset
+	contentEnd := end
+	indent := 0
+	if isMultiline {
+		firstLineEnd := strings.IndexByte(l.source[l.next:end], '\n')
+		if firstLineEnd >= 0 && isBlank(l.source[l.next:l.next+firstLineEnd]) {
+			for l.peek() != '\n' {
+				l.advance()
+			}
+			l.advance()
+		}
+		lastLineStart := strings.LastIndexByte(l.source[l.next:end], '\n')
+		if lastLineStart >= 0 && isBlank(l.source[l.next+lastLineStart+1:end]) {
+			contentEnd = l.next + lastLineStart
 		}
-		value = value + string(l.advance())
+		indent = commonIndent(l.source[l.next:contentEnd])
+	}
+	contentStart := l.next
+	l.skipIndent(indent, contentEnd)
+
+	var segments []StringSegment
+	var text strings.Builder
+	textStart := l.next
+	addText := func(end int) {
+		text.WriteString(l.source[textStart:end])
+		if text.Len() > 0 {
+			segments = append(segments, StringSegment{Text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for l.next < contentEnd {
+		switch c := l.peek(); {
+		case c == '\\' && !raw:
+			text.WriteString(l.source[textStart:l.next])
+			l.advance()
+			if l.next < contentEnd {
+				// the backslash is dropped and the character after it kept as is e.g. \{ for a literal brace
+				escaped := l.next
+				l.advance()
+				for l.next < contentEnd && !utf8.RuneStart(l.source[l.next]) {
+					l.advance()
+				}
+				text.WriteString(l.source[escaped:l.next])
+			}
+			textStart = l.next
+		case c == '{' && !raw:
+			addText(l.next)
+			segments = append(segments, StringSegment{Interpolation: l.lexInterpolation(contentEnd)})
+			textStart = l.next
+		case c == '\n' && indent > 0:
+			l.advance()
+			text.WriteString(l.source[textStart:l.next])
+			l.skipIndent(indent, contentEnd)
+			textStart = l.next
+		default:
+			l.advance()
+		}
+	}
+	addText(contentEnd)
+	for l.next < end+len(quote) {
+		l.advance()
+	}
+
+	// the literal is as written, less any removed indentation, for strings which aren't interpolated
+	l.addStringLiteralToken(dedent(l.source[contentStart:contentEnd], indent), segments)
+}
+
+// lexInterpolation lexes a {...} in a string literal, splitting off any format after a ':' e.g. {price:.2f}. It ends
+// at the first '}' outside any brackets or quotes in its expression, and can't span lines.
+func (l *Lexer) lexInterpolation(end int) *InterpolationTokens {
+	start := l.next
+	l.advance()
+	exprStart, exprLineCharIndex := l.next, l.lineCharIndex
+	exprEnd, formatStart := -1, -1
+	depth := 0
+	var quote rune
+	closed := false
+	for l.next < end && l.peek() != '\n' {
+		c := l.peek()
+		if c == '}' && depth == 0 && quote == 0 {
+			closed = true
+			break
+		}
+		if formatStart < 0 {
+			switch {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '(' || c == '[' || c == '{':
+				depth++
+			case c == ')' || c == ']' || c == '}':
+				depth--
+			case c == ':' && depth == 0:
+				exprEnd, formatStart = l.next, l.next+1
+			}
+		}
+		l.advance()
 	}
-	l.addStringLiteralToken(value)
+
+	end, endLineCharIndex := l.next, l.lineCharIndex
+	if exprEnd < 0 {
+		exprEnd = end
+	}
+	if closed {
+		l.advance()
+	}
+
+	interpolation := &InterpolationTokens{
+		Token:  NewToken(STRING_LITERAL, l.source[start:l.next], start, l.lineIndex, l.lineCharIndex),
+		Expr:   l.lexInterpolatedExpr(exprStart, exprEnd, exprLineCharIndex),
+		Closed: closed,
+	}
+	if formatStart >= 0 {
+		interpolation.Format = NewToken(STRING_LITERAL, l.source[formatStart:end], formatStart, l.lineIndex,
+			endLineCharIndex)
+	}
+	return interpolation
+}
+
+// skipIndent skips up to the given number of spaces or tabs, not going past the end offset.
+func (l *Lexer) skipIndent(indent int, end int) {
+	for skipped := 0; skipped < indent && l.next < end && (l.peek() == ' ' || l.peek() == '\t'); skipped++ {
+		l.advance()
+	}
+}
+
+// lexInterpolatedExpr lexes the source between the given offsets on the current line, giving tokens the positions
+// they have in the full source.
+func (l *Lexer) lexInterpolatedExpr(start int, end int, lineCharIndex int) []Token {
+	sub := NewLexer(l.printer, l.source[:end])
+	sub.start, sub.next = start, start
+	sub.lineIndex, sub.lineCharIndex = l.lineIndex, lineCharIndex
+	return sub.Lex()
 }
 
 func (l *Lexer) lexNumber() {
@@ -332,6 +530,8 @@ func (l *Lexer) lexIdentifier() {
 		l.addBoolLiteralToken(true)
 	} else if text == "false" {
 		l.addBoolLiteralToken(false)
+	} else if text == "null" {
+		l.addToken(NULL_LITERAL)
 	} else {
 		l.addToken(IDENTIFIER)
 	}
@@ -457,9 +657,9 @@ func (l *Lexer) addToken(tokenType TokenType) {
 	l.Tokens = append(l.Tokens, token)
 }
 
-func (l *Lexer) addStringLiteralToken(literal string) {
+func (l *Lexer) addStringLiteralToken(literal string, segments []StringSegment) {
 	lexeme := l.source[l.start:l.next]
-	token := NewStringLiteralToken(STRING_LITERAL, lexeme, l.start, l.lineIndex, l.lineCharIndex, literal)
+	token := NewStringLiteralToken(STRING_LITERAL, lexeme, l.start, l.lineIndex, l.lineCharIndex, literal, segments)
 	l.Tokens = append(l.Tokens, token)
 }
 
@@ -536,8 +736,22 @@ func (l *Lexer) addJsonPathElementToken(jsonPathElement string, isArray bool) {
 }
 
 func (l *Lexer) error(message string) {
+	l.printer.RslErrorExit(RslError{Token: l.errorToken(), Msg: message})
+}
+
+// errorToken is the current lexeme, cut to its first line if it spans several, for errors to point at.
+func (l *Lexer) errorToken() Token {
 	lexeme := l.source[l.start:l.next]
-	lexeme = strings.ReplaceAll(lexeme, "\n", "\\n") // todo, instead should maybe just write the last line?
-	lineStart := l.lineCharIndex - (l.next - l.start - 1)
-	l.printer.ErrorExit(fmt.Sprintf("Error at L%d/%d on '%s': %s\n", l.lineIndex, lineStart, lexeme, message))
+	line := l.lineIndex - strings.Count(lexeme, "\n")
+	if firstLineEnd := strings.IndexByte(lexeme, '\n'); firstLineEnd >= 0 {
+		lexeme = lexeme[:firstLineEnd]
+	}
+	lineStart := strings.LastIndexByte(l.source[:l.start], '\n') + 1
+	endCol := utf8.RuneCountInString(l.source[lineStart:l.start]) + utf8.RuneCountInString(lexeme)
+	return &BaseToken{
+		Lexeme:        lexeme,
+		CharStart:     l.start,
+		Line:          line,
+		CharLineStart: endCol,
+	}
 }
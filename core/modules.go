@@ -0,0 +1,218 @@
+package core
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// RAD_PATH is a list of directories (separated like PATH) to search for modules which cannot be found
+	// relative to the importing script.
+	RAD_PATH = "RAD_PATH"
+)
+
+// moduleRegistry is shared between a script's interpreter and the interpreters of all the modules it
+// (transitively) imports.
+type moduleRegistry struct {
+	// absolute paths of the modules currently being imported, in import order, to detect cycles
+	importStack []string
+	// modules which have finished loading, keyed by absolute path, so diamond imports only run once
+	loaded map[string]*Env
+}
+
+func newModuleRegistry() *moduleRegistry {
+	return &moduleRegistry{
+		importStack: []string{},
+		loaded:      make(map[string]*Env),
+	}
+}
+
+func (i *MainInterpreter) VisitImportStmtStmt(stmt ImportStmt) {
+	rawPath := stmt.Path.Value.Literal
+	namespace := resolveNamespace(i, stmt)
+	path, ok := resolveModulePath(rawPath)
+	if !ok {
+		i.error(stmt.ImportToken, fmt.Sprintf("Could not find module %q relative to %q or in $%s",
+			rawPath, ScriptDir, RAD_PATH))
+	}
+
+	for idx, importing := range i.modules.importStack {
+		if importing == path {
+			cycle := append(i.modules.importStack[idx:], path)
+			cycle = lo.Map(cycle, func(p string, _ int) string { return filepath.Base(p) })
+			i.error(stmt.ImportToken, fmt.Sprintf("Import cycle detected: %s", strings.Join(cycle, " -> ")))
+		}
+	}
+
+	moduleEnv, ok := i.modules.loaded[path]
+	if !ok {
+		moduleEnv = loadModule(i, stmt, rawPath, path)
+		i.modules.loaded[path] = moduleEnv
+	}
+	i.env.SetModule(namespace, moduleEnv)
+}
+
+func (i *MainInterpreter) VisitModuleAccessExpr(access ModuleAccess) interface{} {
+	moduleEnv := i.env.GetModule(access.Module)
+	val, ok := moduleEnv.get(access.Name.GetLexeme(), access.Name)
+	if !ok {
+		i.errorWithHint(access.Name, fmt.Sprintf("Module '%s' has no variable '%s'",
+			access.Module.GetLexeme(), access.Name.GetLexeme()), didYouMean(access.Name.GetLexeme(), moduleEnv.varNames()))
+	}
+	return val.value
+}
+
+// loadModule lexes, parses, type checks and runs the module at the given path, returning the env holding its
+// top-level variables. Errors raised while doing so are attributed to the module's file.
+func loadModule(i *MainInterpreter, stmt ImportStmt, rawPath string, path string) *Env {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		i.error(stmt.ImportToken, fmt.Sprintf("Could not read module %q: %v", rawPath, err))
+	}
+
+	i.modules.importStack = append(i.modules.importStack, path)
+	defer func() {
+		i.modules.importStack = i.modules.importStack[:len(i.modules.importStack)-1]
+	}()
+
+	var moduleEnv *Env
+	inModule(rawPath, path, string(source), func() {
+		statements := checkModule(string(source), newModuleChecker([]string{path}, make(map[string]moduleExports)))
+		moduleI := NewInterpreter(statements)
+		moduleI.modules = i.modules
+		moduleI.Run()
+		moduleEnv = moduleI.env
+	})
+	return moduleEnv
+}
+
+// inModule runs the function with errors attributed to the module's file, and resources (and further imports)
+// resolved relative to the module itself.
+func inModule(rawPath string, path string, source string, run func()) {
+	originalPrinter := RP
+	originalScriptDir := ScriptDir
+	basePrinter := originalPrinter
+	if importingModule, ok := basePrinter.(*modulePrinter); ok {
+		// attribute to the innermost module only
+		basePrinter = importingModule.Printer
+	}
+	RP = &modulePrinter{Printer: basePrinter}
+	ScriptDir = filepath.Dir(path)
+	defer func() {
+		RP = originalPrinter
+		ScriptDir = originalScriptDir
+	}()
+	RP.SetSource(rawPath, source)
+	run()
+}
+
+// checkModule lexes, parses and type checks a module's source, exiting with any errors found, so none are only found
+// partway through running the importing script. Must be called inModule.
+func checkModule(source string, checker *TypeChecker) []Stmt {
+	l := NewLexer(RP, source)
+	l.Lex()
+	p := NewParser(RP, l.Tokens)
+	statements := p.Parse()
+
+	for _, s := range statements {
+		if argBlock, ok := s.(*ArgBlock); ok {
+			RP.TokenErrorExit(argBlock.ArgsKeyword, "Imported modules cannot declare an args block\n")
+		}
+	}
+
+	if errs := checker.Check(statements); len(errs) > 0 {
+		RP.TokenErrorsExit(errs)
+	}
+	return statements
+}
+
+func resolveNamespace(i *MainInterpreter, stmt ImportStmt) string {
+	namespace := moduleNamespace(stmt)
+	if stmt.Alias == nil && !isValidIdentifier(namespace) {
+		i.error(stmt.ImportToken, fmt.Sprintf("Cannot use %q as a namespace, name one with 'import %q as <name>'",
+			namespace, stmt.Path.Value.Literal))
+	}
+	return namespace
+}
+
+// moduleNamespace is the alias if given, otherwise the module's file name without its extension.
+func moduleNamespace(stmt ImportStmt) string {
+	if stmt.Alias != nil {
+		return (*stmt.Alias).GetLexeme()
+	}
+
+	base := filepath.Base(stmt.Path.Value.Literal)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// resolveModulePath looks for the module relative to the importing script first, and then in each of the
+// $RAD_PATH directories. Returns the absolute path of the first match.
+func resolveModulePath(pathFromRslScript string) (string, bool) {
+	candidates := []string{resolveFinalPath(pathFromRslScript)}
+	if !filepath.IsAbs(pathFromRslScript) {
+		radPath, _ := REnvVars.Lookup(RAD_PATH)
+		for _, dir := range filepath.SplitList(radPath) {
+			if dir != "" {
+				candidates = append(candidates, filepath.Join(dir, pathFromRslScript))
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			abs, err := filepath.Abs(candidate)
+			if err != nil {
+				return candidate, true
+			}
+			return abs, true
+		}
+	}
+	return "", false
+}
+
+func isValidIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for idx, c := range s {
+		if !(isAlpha(c) || (idx > 0 && (isDigit(c) || c == '_'))) {
+			return false
+		}
+	}
+	return true
+}
+
+// == modulePrinter ==
+
+// modulePrinter attributes errors to the module file in which they occurred.
+type modulePrinter struct {
+	Printer
+	source sourceFile
+}
+
+func (p *modulePrinter) ErrorExit(msg string) {
+	p.Printer.ErrorExit(fmt.Sprintf("In %s: %s", p.source.path, msg))
+}
+
+func (p *modulePrinter) TokenErrorExit(token Token, msg string) {
+	if token == nil {
+		p.ErrorExit(msg)
+		return
+	}
+	p.RslErrorExit(RslError{Token: token, Msg: strings.TrimSuffix(msg, "\n")})
+}
+
+func (p *modulePrinter) RslErrorExit(err RslError) {
+	p.TokenErrorsExit([]RslError{err})
+}
+
+func (p *modulePrinter) TokenErrorsExit(errs []RslError) {
+	p.Printer.ErrorExit(renderDiagnostics(p.source, SEVERITY_ERROR, errs, shouldColor(RIo.StdErr)))
+}
+
+func (p *modulePrinter) SetSource(path string, source string) {
+	p.source = newSourceFile(path, source)
+}
@@ -12,6 +12,10 @@ const (
 	RslIntArrayT
 	RslFloatArrayT
 	RslBoolArrayT
+	RslNullT
+	RslDateT
+	RslDateTimeT
+	RslDurationT
 )
 
 func (r *RslTypeEnum) IsArray() bool {
@@ -22,11 +26,50 @@ func (r *RslTypeEnum) IsArray() bool {
 	return *r == RslArrayT
 }
 
+func (r RslTypeEnum) AsString() string {
+	switch r {
+	case RslStringT:
+		return "string"
+	case RslIntT:
+		return "int"
+	case RslFloatT:
+		return "float"
+	case RslBoolT:
+		return "bool"
+	case RslArrayT:
+		return "mixed array"
+	case RslStringArrayT:
+		return "string[]"
+	case RslIntArrayT:
+		return "int[]"
+	case RslFloatArrayT:
+		return "float[]"
+	case RslBoolArrayT:
+		return "bool[]"
+	case RslNullT:
+		return "null"
+	case RslDateT:
+		return "date"
+	case RslDateTimeT:
+		return "datetime"
+	case RslDurationT:
+		return "duration"
+	default:
+		return "unknown"
+	}
+}
+
 type RslType struct {
 	Token Token
 	Type  RslTypeEnum
 }
 
+// NamedArg is an arg given to a function by its param's name e.g. prompt="Env?"
+type NamedArg struct {
+	Name  Token
+	Value Expr
+}
+
 type JsonPath struct {
 	elements []JsonPathElement
 }
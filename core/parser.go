@@ -7,6 +7,8 @@ import (
 
 const (
 	onlyOneReturnValueAllowed = "Binary operators are only allowed in expressions with one return value"
+	// parsing stops after this many errors, as later ones are increasingly likely to be noise caused by earlier ones
+	MAX_PARSE_ERRORS = 10
 )
 
 type Parser struct {
@@ -14,8 +16,12 @@ type Parser struct {
 	tokens              []Token
 	next                int
 	nestedForBlockLevel int
+	errors              []RslError
 }
 
+// parseError is panicked by Parser.error to unwind to the nearest statement, from which parsing recovers.
+type parseError struct{}
+
 func NewParser(printer Printer, tokens []Token) *Parser {
 	return &Parser{
 		printer: printer,
@@ -30,16 +36,20 @@ func (p *Parser) Parse() []Stmt {
 	p.consumeNewlines()
 	p.fileHeaderIfPresent(&statements)
 	p.consumeNewlines()
-	p.argBlockIfPresent(&statements)
+	p.recovering(func() { p.argBlockIfPresent(&statements) })
 	p.consumeNewlines()
 
 	for !p.isAtEnd() {
-		s := p.statement()
-		if _, ok := s.(*Empty); !ok {
+		s := p.statementRecovering()
+		if _, ok := s.(*Empty); !ok && s != nil {
 			statements = append(statements, s)
 		}
 		p.consumeNewlines()
 	}
+
+	if len(p.errors) > 0 {
+		p.printer.TokenErrorsExit(p.errors)
+	}
 	return statements
 }
 
@@ -53,13 +63,10 @@ func (p *Parser) peekType(tokenType TokenType) bool {
 
 func (p *Parser) peekTypeSeries(tokenType ...TokenType) bool {
 	for i, t := range tokenType {
-		token := p.advance()
-		if token.GetType() != t {
-			p.next -= i + 1
+		if p.next+i >= len(p.tokens) || p.tokens[p.next+i].GetType() != t {
 			return false
 		}
 	}
-	p.next -= len(tokenType)
 	return true
 }
 
@@ -113,8 +120,113 @@ func (p *Parser) tryConsume(tokenType TokenType) (Token, bool) {
 
 // todo this func is susceptible to pointing at an uninformative token
 func (p *Parser) error(message string) {
-	currentToken := p.tokens[p.next]
-	p.printer.TokenErrorExit(currentToken, message+"\n")
+	p.recordError(message)
+	panic(parseError{})
+}
+
+// errorAt is like error, but points to the given token rather than the current one.
+func (p *Parser) errorAt(token Token, message string, hint string) {
+	p.addError(RslError{Token: token, Msg: message, Hint: hint})
+	panic(parseError{})
+}
+
+// recordError is like error, but continues parsing from where it is, for errors found in otherwise parsable code.
+func (p *Parser) recordError(message string) {
+	p.addError(RslError{Token: p.tokens[p.next], Msg: message})
+}
+
+func (p *Parser) addError(err RslError) {
+	for _, existing := range p.errors {
+		if existing.Msg == err.Msg && existing.Token != nil && err.Token != nil &&
+			existing.Token.GetCharStart() == err.Token.GetCharStart() {
+			return // already reported, e.g. when recovery stops on the token it failed on
+		}
+	}
+	p.errors = append(p.errors, err)
+	if len(p.errors) >= MAX_PARSE_ERRORS {
+		p.errors = append(p.errors, RslError{Msg: fmt.Sprintf("Stopped after %d errors", MAX_PARSE_ERRORS)})
+		p.printer.TokenErrorsExit(p.errors)
+	}
+}
+
+// statementRecovering parses a statement, returning nil if it has an error, in which case the rest of it is skipped
+// so parsing may continue from the next statement, and further errors be found.
+func (p *Parser) statementRecovering() (stmt Stmt) {
+	p.recovering(func() { stmt = p.statement() })
+	return
+}
+
+// recovering runs the parse, returning false if it had an error, in which case the rest of the statement it was
+// parsing is skipped.
+func (p *Parser) recovering(parse func()) (ok bool) {
+	nestedForBlockLevel := p.nestedForBlockLevel
+	start := p.next
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isParseError := r.(parseError); !isParseError {
+				panic(r)
+			}
+			p.nestedForBlockLevel = nestedForBlockLevel
+			p.synchronize(start)
+			ok = false
+		}
+	}()
+	parse()
+	return true
+}
+
+// synchronize skips to the start of the next statement, passing over any block opened by the current one (which
+// began at token index 'start'), and any 'else' or 'catch' blocks following it. Stops before the dedent ending the
+// enclosing block, if there is one. Always skips at least one token, so parsing can't get stuck on one.
+func (p *Parser) synchronize(start int) {
+	depth := 0
+	for _, token := range p.tokens[start:p.next] {
+		switch token.GetType() {
+		case INDENT:
+			depth++
+		case DEDENT:
+			depth = max(depth-1, 0)
+		}
+	}
+	if p.next == start && !p.isAtEnd() {
+		// the statement failed on its first token, so skip it, else we'd fail on it again
+		if p.peekType(INDENT) {
+			depth++
+		}
+		p.advance()
+	}
+
+	for !p.isAtEnd() {
+		switch p.peek().GetType() {
+		case INDENT:
+			depth++
+		case DEDENT:
+			if depth == 0 {
+				return
+			}
+			depth--
+			if depth == 0 {
+				p.advance()
+				if p.isStatementContinuation() {
+					continue
+				}
+				return
+			}
+		case NEWLINE:
+			if depth == 0 {
+				p.consumeNewlines()
+				if p.peekType(INDENT) || p.isStatementContinuation() {
+					continue
+				}
+				return
+			}
+		}
+		p.advance()
+	}
+}
+
+func (p *Parser) isStatementContinuation() bool {
+	return p.peekKeyword(ELSE, GLOBAL_KEYWORDS) || p.peekKeyword(CATCH, GLOBAL_KEYWORDS)
 }
 
 func (p *Parser) fileHeaderIfPresent(statements *[]Stmt) {
@@ -137,9 +249,10 @@ func (p *Parser) argBlockIfPresent(statements *[]Stmt) {
 
 		p.consumeNewlines()
 		argsBlock := ArgBlock{ArgsKeyword: argsKeyword, Stmts: []ArgStmt{}}
-		for !p.matchAny(DEDENT) {
-			s := p.argStatement()
-			argsBlock.Stmts = append(argsBlock.Stmts, s)
+		for !p.matchAny(DEDENT) && !p.isAtEnd() {
+			p.recovering(func() {
+				argsBlock.Stmts = append(argsBlock.Stmts, p.argStatement())
+			})
 			p.consumeNewlines()
 		}
 		*statements = append(*statements, &argsBlock)
@@ -148,36 +261,79 @@ func (p *Parser) argBlockIfPresent(statements *[]Stmt) {
 
 // argBlockConstraint       -> argStringRegexConstraint
 //
-//	| argIntRangeConstraint
+//	| argNumberRangeConstraint
 //	| argOneWayReq
 //	| argMutualExcl
+//	| argsSpecifiedConstraint
 //
-// argStringRegexConstraint -> IDENTIFIER ( "," IDENTIFIER )* "not"? "regex" REGEX
-// argIntRangeConstraint    -> IDENTIFIER COMPARATORS INT
-// argOneWayReq             -> IDENTIFIER "requires" IDENTIFIER
+// argStringRegexConstraint -> IDENTIFIER ( "," IDENTIFIER )* "not"? "regex" STRING
+// argNumberRangeConstraint -> IDENTIFIER COMPARATORS "-"? NUMBER
+// argOneWayReq             -> IDENTIFIER "requires" IDENTIFIER ( "," IDENTIFIER )*
 // argMutualExcl            -> "one_of" IDENTIFIER ( "," IDENTIFIER )+
+// argsSpecifiedConstraint  -> ( "at_least" | "exactly" | "at_most" ) INT ":"? IDENTIFIER ( "," IDENTIFIER )+
 func (p *Parser) argStatement() ArgStmt {
 	if p.matchKeyword(ONE_OF, ARGS_BLOCK_KEYWORDS) {
-		panic(NOT_IMPLEMENTED)
+		oneOfToken := p.previous()
+		identifiers := p.argIdentifiers(2, "one_of")
+		return &ArgOneOf{OneOfToken: oneOfToken, Identifiers: identifiers}
+	}
+
+	if p.matchKeyword(AT_LEAST, ARGS_BLOCK_KEYWORDS) ||
+		p.matchKeyword(EXACTLY, ARGS_BLOCK_KEYWORDS) ||
+		p.matchKeyword(AT_MOST, ARGS_BLOCK_KEYWORDS) {
+
+		countToken := p.previous()
+		count := p.consume(INT_LITERAL, fmt.Sprintf("Expected number of args after '%s'", countToken.GetLexeme()))
+		p.matchAny(COLON)
+		identifiers := p.argIdentifiers(2, countToken.GetLexeme())
+		return &ArgCount{CountToken: countToken, Count: *count.(*IntLiteralToken), Identifiers: identifiers}
 	}
 
 	identifier := p.consume(IDENTIFIER, "Expected Identifier or keyword")
 
-	if p.peekType(STRING_LITERAL) ||
-		p.peekType(IDENTIFIER) ||
-		p.peekType(STRING) ||
-		p.peekType(INT_LITERAL) ||
-		p.peekType(BOOL) {
+	if p.matchKeyword(REQUIRES, ARGS_BLOCK_KEYWORDS) {
+		requiresToken := p.previous()
+		required := p.argIdentifiers(1, "requires")
+		return &ArgRequires{Identifier: identifier, RequiresToken: requiresToken, Required: required}
+	}
 
-		return p.argDeclaration(identifier)
+	if p.peekType(COMMA) || p.peekKeyword(NOT, GLOBAL_KEYWORDS) || p.peekKeyword(REGEX, ARGS_BLOCK_KEYWORDS) {
+		return p.argRegex(identifier)
 	}
 
-	if p.matchKeyword(REQUIRES, ARGS_BLOCK_KEYWORDS) {
-		panic(NOT_IMPLEMENTED)
+	if p.matchAny(GREATER, GREATER_EQUAL, LESS, LESS_EQUAL) {
+		comparator := p.previous()
+		negative := p.matchAny(MINUS)
+		if !p.matchAny(INT_LITERAL, FLOAT_LITERAL) {
+			p.error(fmt.Sprintf("Expected number after '%s'", comparator.GetLexeme()))
+		}
+		return &ArgRange{Identifier: identifier, Comparator: comparator, Negative: negative, Bound: p.previous()}
 	}
 
-	// todo rest
-	panic(NOT_IMPLEMENTED)
+	return p.argDeclaration(identifier)
+}
+
+func (p *Parser) argRegex(identifier Token) ArgStmt {
+	identifiers := []Token{identifier}
+	for p.matchAny(COMMA) {
+		identifiers = append(identifiers, p.consume(IDENTIFIER, "Expected arg name after ','"))
+	}
+	negated := p.matchKeyword(NOT, GLOBAL_KEYWORDS)
+	regexToken := p.consumeKeyword(REGEX, ARGS_BLOCK_KEYWORDS)
+	regex := p.consume(STRING_LITERAL, "Expected regex string after 'regex'")
+	return &ArgRegex{Identifiers: identifiers, Negated: negated, RegexToken: regexToken, Regex: *regex.(*StringLiteralToken)}
+}
+
+// argIdentifiers consumes a comma-separated list of at least min arg names.
+func (p *Parser) argIdentifiers(min int, after string) []Token {
+	identifiers := []Token{p.consume(IDENTIFIER, fmt.Sprintf("Expected arg name after '%s'", after))}
+	for p.matchAny(COMMA) {
+		identifiers = append(identifiers, p.consume(IDENTIFIER, "Expected arg name after ','"))
+	}
+	if len(identifiers) < min {
+		p.error(fmt.Sprintf("'%s' needs at least %d args", after, min))
+	}
+	return identifiers
 }
 
 func (p *Parser) argDeclaration(identifier Token) ArgStmt {
@@ -187,7 +343,7 @@ func (p *Parser) argDeclaration(identifier Token) ArgStmt {
 	}
 
 	var flag Token
-	if p.peekTwoAhead().GetType() == IDENTIFIER {
+	if isArgTypeKeyword(p.peekTwoAhead()) {
 		if p.peekType(IDENTIFIER) {
 			// non-int flag
 			flag = p.consume(IDENTIFIER, "Expected Flag")
@@ -202,6 +358,44 @@ func (p *Parser) argDeclaration(identifier Token) ArgStmt {
 		p.error("Mixed-type arrays are not allowed in arg declaration")
 	}
 
+	var extensions *StringArrayLiteral
+	if p.peekType(LEFT_BRACKET) && ARGS_BLOCK_KEYWORDS[rslType.Token.GetLexeme()] == PATH {
+		values := p.stringArrayLiteral()
+		if len(values.Values) == 0 {
+			p.error("Expected at least one file extension to complete the path with")
+		}
+		extensions = &values
+	}
+
+	isSecret := false
+	if p.peekKeyword(SECRET, ARGS_BLOCK_KEYWORDS) {
+		if rslType.Type != RslStringT && rslType.Type != RslStringArrayT {
+			p.error(fmt.Sprintf("Only string args may be secret, but '%s' is %s",
+				identifier.GetLexeme(), rslType.Type.AsString()))
+		}
+		p.consumeKeyword(SECRET, ARGS_BLOCK_KEYWORDS)
+		isSecret = true
+	}
+
+	var enum *StringArrayLiteral
+	if p.peekKeyword(IN, GLOBAL_KEYWORDS) {
+		if rslType.Type != RslStringT && rslType.Type != RslStringArrayT {
+			p.error(fmt.Sprintf("Only string args may be restricted to a set of values, but '%s' is %s",
+				identifier.GetLexeme(), rslType.Type.AsString()))
+		}
+		p.consumeKeyword(IN, GLOBAL_KEYWORDS)
+		values := p.stringArrayLiteral()
+		if len(values.Values) == 0 {
+			p.error("Expected at least one value to restrict the arg to")
+		}
+		enum = &values
+	}
+
+	var envVar *StringLiteralToken
+	if p.matchKeyword(ENV, ARGS_BLOCK_KEYWORDS) {
+		envVar = p.consume(STRING_LITERAL, "Expected environment variable name after 'env'").(*StringLiteralToken)
+	}
+
 	isOptional := false
 	var defaultLiteral LiteralOrArray
 	if p.matchAny(QUESTION) {
@@ -209,6 +403,10 @@ func (p *Parser) argDeclaration(identifier Token) ArgStmt {
 	} else if p.matchAny(EQUAL) {
 		isOptional = true
 		rslTypeEnum := rslType.Type
+		if isTimeType(rslTypeEnum) {
+			// parsed like values given on the command line e.g. "-7d", so relative defaults are relative to when run
+			rslTypeEnum = RslStringT
+		}
 		defaultLiteralIfPresent, ok := p.literalOrArray(&rslTypeEnum)
 		if !ok {
 			p.error("Expected default value")
@@ -227,12 +425,29 @@ func (p *Parser) argDeclaration(identifier Token) ArgStmt {
 		Rename:     &renameLiteral,
 		Flag:       &flag,
 		ArgType:    rslType,
+		IsSecret:   isSecret,
 		IsOptional: isOptional,
 		Default:    &defaultLiteral,
+		Enum:       enum,
+		EnvVar:     envVar,
+		Extensions: extensions,
 		Comment:    argComment,
 	}
 }
 
+// isArgTypeKeyword is true for the keywords starting an arg's type e.g. 'string', which follow its flag, if it has one
+func isArgTypeKeyword(token Token) bool {
+	if token.GetType() != IDENTIFIER {
+		return false
+	}
+	switch ARGS_BLOCK_KEYWORDS[token.GetLexeme()] {
+	case STRING, INT, FLOAT, BOOL, ARRAY, DATE, DATETIME, DURATION, PATH, DIR:
+		return true
+	default:
+		return false
+	}
+}
+
 func (p *Parser) statement() Stmt {
 	if p.matchKeyword(RAD, GLOBAL_KEYWORDS) {
 		return p.radBlock(Rad)
@@ -246,24 +461,36 @@ func (p *Parser) statement() Stmt {
 		return p.radBlock(Display)
 	}
 
+	if p.peekKeyword(IMPORT, GLOBAL_KEYWORDS) {
+		return p.importStmt()
+	}
+
 	if p.peekKeyword(IF, GLOBAL_KEYWORDS) {
 		return p.ifStmt()
 	}
 
+	if p.peekKeyword(TRY, GLOBAL_KEYWORDS) {
+		return p.tryStmt()
+	}
+
 	if p.peekKeyword(FOR, GLOBAL_KEYWORDS) {
 		return p.forStmt()
 	}
 
+	if p.peekKeyword(WHILE, GLOBAL_KEYWORDS) {
+		return p.whileStmt()
+	}
+
 	if p.peekKeyword(BREAK, GLOBAL_KEYWORDS) {
 		if p.nestedForBlockLevel == 0 {
-			p.error("Break statement must be inside a for loop")
+			p.recordError("Break statement must be inside a loop")
 		}
 		return &BreakStmt{BreakToken: p.consumeKeyword(BREAK, GLOBAL_KEYWORDS)}
 	}
 
 	if p.peekKeyword(CONTINUE, GLOBAL_KEYWORDS) {
 		if p.nestedForBlockLevel == 0 {
-			p.error("Continue statement must be inside a for loop")
+			p.recordError("Continue statement must be inside a loop")
 		}
 		return &ContinueStmt{ContinueToken: p.consumeKeyword(CONTINUE, GLOBAL_KEYWORDS)}
 	}
@@ -292,6 +519,7 @@ func (p *Parser) radBlock(radType RadBlockType) *RadBlock {
 	}
 
 	var radStatements []RadStmt
+	valid := true
 	if p.peekType(COLON) || p.nextNonNewLineTokenIs(INDENT) { // todo i think this breaks if there's a newline between the colon and indent
 		if radType != Display {
 			p.consume(COLON, fmt.Sprintf("Expecting ':' to precede indented %v block", radType))
@@ -302,13 +530,19 @@ func (p *Parser) radBlock(radType RadBlockType) *RadBlock {
 		}
 		p.consumeNewlines()
 		for !p.matchAny(DEDENT, EOF) {
-			radStatements = append(radStatements, p.radStatement(radType))
+			ok := p.recovering(func() {
+				radStatements = append(radStatements, p.radStatement(radType))
+			})
+			valid = valid && ok
 			p.consumeNewlines()
 		}
 	}
 
 	radBlock := &RadBlock{RadKeyword: radToken, RadType: radType, Source: srcToken, Stmts: radStatements}
-	p.validateRadBlock(radBlock)
+	if valid {
+		// a statement which failed to parse may have satisfied what's missing, so only check complete blocks
+		p.validateRadBlock(radBlock)
+	}
 	return radBlock
 }
 
@@ -332,14 +566,15 @@ func (p *Parser) radStatement(radType RadBlockType) RadStmt {
 
 	var mods []RadFieldModStmt
 	for !p.matchAny(DEDENT, EOF) {
-		p.consumeNewlines()
 		if p.matchKeyword(TRUNCATE, RAD_BLOCK_KEYWORDS) {
 			mods = append(mods, p.truncStmt())
-		}
-		if p.matchKeyword(COLOR, RAD_BLOCK_KEYWORDS) {
+		} else if p.matchKeyword(COLOR, RAD_BLOCK_KEYWORDS) {
 			mods = append(mods, p.colorStmt())
+		} else {
+			// todo other field mod stmts
+			p.error("Expected 'truncate' or 'color' in field modifier block")
 		}
-		// todo other field mod stmts
+		p.consumeNewlines()
 	}
 	return &FieldMods{Identifiers: identifiers, Mods: mods}
 
@@ -367,7 +602,7 @@ func (p *Parser) validateRadBlock(radBlock *RadBlock) {
 		switch stmt := stmt.(type) {
 		case *Fields:
 			if hasFieldsStmt {
-				p.error(fmt.Sprintf("Only one 'fields' statement is allowed in a %s block", radBlock.RadType))
+				p.recordError(fmt.Sprintf("Only one 'fields' statement is allowed in a %s block", radBlock.RadType))
 			}
 			hasFieldsStmt = true
 			// move field statement to the front, so it gets processed first later
@@ -379,11 +614,11 @@ func (p *Parser) validateRadBlock(radBlock *RadBlock) {
 			stmtsRequiringFields = append(stmtsRequiringFields, "field modifiers")
 			reorderedStmts = append(reorderedStmts, stmt)
 		default:
-			p.error(fmt.Sprintf("Bug! Unhandled statement type in rad block: %v", stmt))
+			p.recordError(fmt.Sprintf("Bug! Unhandled statement type in rad block: %v", stmt))
 		}
 	}
 	if len(stmtsRequiringFields) > 0 && !hasFieldsStmt {
-		p.error(fmt.Sprintf("Missing 'fields' statement required by %v statements: %v",
+		p.recordError(fmt.Sprintf("Missing 'fields' statement required by %v statements: %v",
 			radBlock.RadType, stmtsRequiringFields))
 	}
 }
@@ -473,10 +708,48 @@ func (p *Parser) ifCase() IfCase {
 	return IfCase{IfToken: ifToken, Condition: condition, Body: block}
 }
 
+func (p *Parser) tryStmt() Stmt {
+	tryToken := p.consumeKeyword(TRY, GLOBAL_KEYWORDS)
+	p.consume(COLON, "Expected ':' after 'try'")
+	p.consumeNewlines()
+	p.consume(INDENT, "Expected indented block after 'try'")
+	tryBlock := p.block()
+
+	if !p.matchKeyword(CATCH, GLOBAL_KEYWORDS) {
+		p.error("Expected 'catch' block after 'try' block")
+	}
+	catchToken := p.previous()
+	var errIdentifier *Token
+	var locIdentifier *Token
+	if p.matchAny(IDENTIFIER) {
+		e := p.previous()
+		errIdentifier = &e
+		if p.matchAny(COMMA) {
+			l := p.consume(IDENTIFIER, "Expected identifier after ','")
+			locIdentifier = &l
+		}
+	}
+	p.consume(COLON, "Expected ':' after 'catch'")
+	p.consumeNewlines()
+	p.consume(INDENT, "Expected indented block after 'catch'")
+	catchBlock := p.block()
+
+	return &TryStmt{
+		TryToken:      tryToken,
+		TryBlock:      tryBlock,
+		CatchToken:    catchToken,
+		ErrIdentifier: errIdentifier,
+		LocIdentifier: locIdentifier,
+		CatchBlock:    catchBlock,
+	}
+}
+
 func (p *Parser) block() Block {
 	var stmts []Stmt
-	for !p.matchAny(DEDENT) {
-		stmts = append(stmts, p.statement())
+	for !p.matchAny(DEDENT) && !p.isAtEnd() {
+		if s := p.statementRecovering(); s != nil {
+			stmts = append(stmts, s)
+		}
 		p.consumeNewlines()
 	}
 	return Block{Stmts: stmts}
@@ -501,6 +774,35 @@ func (p *Parser) forStmt() ForStmt {
 	return ForStmt{ForToken: forToken, Identifier1: identifier1, Identifier2: identifier2, Range: rangeExpr, Body: block}
 }
 
+func (p *Parser) whileStmt() Stmt {
+	whileToken := p.consumeKeyword(WHILE, GLOBAL_KEYWORDS)
+	condition := p.expr(1)
+	p.consume(COLON, "Expected ':' after while condition")
+	p.consumeNewlines()
+	p.consume(INDENT, "Expected indented block after while")
+	p.nestedForBlockLevel += 1
+	block := p.block()
+	p.nestedForBlockLevel -= 1
+	return &WhileStmt{WhileToken: whileToken, Condition: condition, Body: block}
+}
+
+func (p *Parser) importStmt() Stmt {
+	importToken := p.consumeKeyword(IMPORT, GLOBAL_KEYWORDS)
+	if !p.peekType(STRING_LITERAL) {
+		p.error("Expected path to a .rad file after 'import'")
+	}
+	path := p.stringLiteral()
+	var alias *Token
+	if p.matchKeyword(AS, GLOBAL_KEYWORDS) {
+		a := p.consume(IDENTIFIER, "Expected namespace identifier after 'as'")
+		alias = &a
+	}
+	if !p.isAtEnd() {
+		p.consume(NEWLINE, "Expected newline after import statement")
+	}
+	return &ImportStmt{ImportToken: importToken, Path: path, Alias: alias}
+}
+
 func (p *Parser) functionCallStmt() Stmt {
 	functionCall := p.functionCall(NO_NUM_RETURN_VALUES_CONSTRAINT)
 	return &FunctionStmt{Call: functionCall}
@@ -511,7 +813,7 @@ func (p *Parser) assignment() Stmt {
 	var rslTypes []*RslType
 	identifiers = append(identifiers, p.identifier())
 
-	if p.matchAny(PLUS_EQUAL, MINUS_EQUAL, STAR_EQUAL, SLASH_EQUAL) {
+	if p.matchAny(PLUS_EQUAL, MINUS_EQUAL, STAR_EQUAL, SLASH_EQUAL, PERCENT_EQUAL) {
 		return p.compoundAssignment(identifiers[0], p.previous())
 	}
 
@@ -563,6 +865,8 @@ func (p *Parser) compoundAssignment(identifier Token, operator Token) Stmt {
 		return &CompoundAssign{Name: identifier, Operator: operator, Value: expr}
 	case SLASH_EQUAL:
 		return &CompoundAssign{Name: identifier, Operator: operator, Value: expr}
+	case PERCENT_EQUAL:
+		return &CompoundAssign{Name: identifier, Operator: operator, Value: expr}
 	default:
 		p.error("Invalid compound assignment operator")
 		panic(UNREACHABLE)
@@ -602,8 +906,10 @@ func (p *Parser) switchBlock(identifiers []Token) SwitchBlock {
 	p.consume(INDENT, "Expected indented block after switch")
 
 	var stmts []SwitchStmt
-	for !p.matchAny(DEDENT) {
-		stmts = append(stmts, p.switchStmt(discriminator != nil, len(identifiers)))
+	for !p.matchAny(DEDENT) && !p.isAtEnd() {
+		p.recovering(func() {
+			stmts = append(stmts, p.switchStmt(discriminator != nil, len(identifiers)))
+		})
 		p.consumeNewlines()
 	}
 	return SwitchBlock{SwitchToken: switchToken, Discriminator: &discriminator, Stmts: stmts}
@@ -672,11 +978,26 @@ func (p *Parser) primaryAssignment(identifiers []Token, expectedType []*RslType)
 }
 
 func (p *Parser) expr(numExpectedReturnValues int) Expr {
-	return p.or(numExpectedReturnValues)
+	return p.coalesce(numExpectedReturnValues)
+}
+
+func (p *Parser) coalesce(numExpectedReturnValues int) Expr {
+	expr := p.or(numExpectedReturnValues)
+
+	for p.matchAny(QUESTION_QUESTION) {
+		if numExpectedReturnValues != 1 {
+			p.error(onlyOneReturnValueAllowed)
+		}
+		operator := p.previous()
+		right := p.or(1)
+		expr = &Logical{Left: expr, Operator: operator, Right: right}
+	}
+
+	return expr
 }
 
 func (p *Parser) or(numExpectedReturnValues int) Expr {
-	expr := p.and(numExpectedReturnValues)
+	expr := p.fallible(numExpectedReturnValues)
 
 	for p.matchKeyword(OR, ALL_KEYWORDS) {
 		if numExpectedReturnValues != 1 {
@@ -690,6 +1011,20 @@ func (p *Parser) or(numExpectedReturnValues int) Expr {
 	return expr
 }
 
+func (p *Parser) fallible(numExpectedReturnValues int) Expr {
+	if !p.matchKeyword(TRY, GLOBAL_KEYWORDS) {
+		return p.and(numExpectedReturnValues)
+	}
+
+	tryToken := p.previous()
+	value := p.and(numExpectedReturnValues)
+	if !p.matchKeyword(OR, GLOBAL_KEYWORDS) {
+		p.error("Expected 'or' followed by a fallback value after 'try' expression")
+	}
+	fallback := p.and(numExpectedReturnValues)
+	return &Fallible{TryToken: tryToken, Value: value, Fallback: fallback}
+}
+
 func (p *Parser) and(numExpectedReturnValues int) Expr {
 	expr := p.equality(numExpectedReturnValues)
 
@@ -708,13 +1043,25 @@ func (p *Parser) and(numExpectedReturnValues int) Expr {
 func (p *Parser) equality(numExpectedReturnValues int) Expr {
 	expr := p.comparison(numExpectedReturnValues)
 
-	for p.matchAny(NOT_EQUAL, EQUAL_EQUAL) {
-		if numExpectedReturnValues != 1 {
-			p.error(onlyOneReturnValueAllowed)
+	for {
+		if p.matchAny(NOT_EQUAL, EQUAL_EQUAL) {
+			if numExpectedReturnValues != 1 {
+				p.error(onlyOneReturnValueAllowed)
+			}
+			operator := p.previous()
+			right := p.comparison(1)
+			expr = &Binary{Left: expr, Operator: operator, Right: right}
+		} else if p.matchKeyword(IS, GLOBAL_KEYWORDS) {
+			if numExpectedReturnValues != 1 {
+				p.error(onlyOneReturnValueAllowed)
+			}
+			isToken := p.previous()
+			negated := p.matchKeyword(NOT, GLOBAL_KEYWORDS)
+			p.consume(NULL_LITERAL, "Expected 'null' after 'is'")
+			expr = &NullCheck{Value: expr, IsToken: isToken, Negated: negated}
+		} else {
+			break
 		}
-		operator := p.previous()
-		right := p.comparison(1)
-		expr = &Binary{Left: expr, Operator: operator, Right: right}
 	}
 
 	return expr
@@ -753,7 +1100,7 @@ func (p *Parser) term(numExpectedReturnValues int) Expr {
 func (p *Parser) factor(numExpectedReturnValues int) Expr {
 	expr := p.unary(numExpectedReturnValues)
 
-	for p.matchAny(SLASH, STAR) {
+	for p.matchAny(SLASH, STAR, PERCENT) {
 		if numExpectedReturnValues != 1 {
 			p.error(onlyOneReturnValueAllowed)
 		}
@@ -772,7 +1119,23 @@ func (p *Parser) unary(numExpectedReturnValues int) Expr {
 		return &Unary{Operator: operator, Right: right}
 	}
 
-	return p.primary(numExpectedReturnValues)
+	return p.power(numExpectedReturnValues)
+}
+
+// power is right-associative, and binds tighter than a unary operator on its left, so -2 ** 2 is -4
+func (p *Parser) power(numExpectedReturnValues int) Expr {
+	expr := p.primary(numExpectedReturnValues)
+
+	if p.matchAny(STAR_STAR) {
+		if numExpectedReturnValues != 1 {
+			p.error(onlyOneReturnValueAllowed)
+		}
+		operator := p.previous()
+		right := p.unary(1)
+		expr = &Binary{Left: expr, Operator: operator, Right: right}
+	}
+
+	return expr
 }
 
 func (p *Parser) primary(numExpectedReturnValues int) Expr {
@@ -782,6 +1145,8 @@ func (p *Parser) primary(numExpectedReturnValues int) Expr {
 		expr = p.expr(1)
 		p.consume(RIGHT_PAREN, "Expected ')' after expression")
 		expr = &Grouping{Value: expr}
+	} else if p.peekType(STRING_LITERAL) {
+		return &ExprLoa{Value: &LoaLiteral{Value: p.interpolatedStringLiteral()}}
 	} else if literal, ok := p.literal(nil); ok {
 		return &ExprLoa{Value: &LoaLiteral{Value: literal}}
 	} else if arrayExpr, ok := p.arrayExpr(); ok {
@@ -792,6 +1157,13 @@ func (p *Parser) primary(numExpectedReturnValues int) Expr {
 		if p.peekType(LEFT_PAREN) {
 			p.rewind()
 			expr = p.functionCall(numExpectedReturnValues)
+		} else if p.peekTypeSeries(DOT, IDENTIFIER) {
+			p.consume(DOT, "Expected '.' after module name")
+			name := p.consume(IDENTIFIER, "Expected variable name after '.'")
+			if p.peekType(LEFT_PAREN) {
+				p.error("Modules only export variables, they cannot be called")
+			}
+			expr = &ModuleAccess{Module: identifier, Name: name}
 		} else {
 			expr = &Variable{Name: identifier}
 		}
@@ -813,14 +1185,28 @@ func (p *Parser) functionCall(numExpectedReturnValues int) FunctionCall {
 	function := p.consume(IDENTIFIER, "Expected function name")
 	p.consume(LEFT_PAREN, "Expected '(' after function name")
 	var args []Expr
-	if !p.matchAny(RIGHT_PAREN) {
-		args = append(args, p.expr(1))
-		for !p.matchAny(RIGHT_PAREN) {
+	var namedArgs []NamedArg
+	for !p.matchAny(RIGHT_PAREN) {
+		if len(args) > 0 || len(namedArgs) > 0 {
 			p.consume(COMMA, "Expected ',' between function arguments")
+		}
+		if p.peekType(IDENTIFIER) && p.peekTwoAhead().GetType() == EQUAL {
+			name := p.advance()
+			p.advance()
+			namedArgs = append(namedArgs, NamedArg{Name: name, Value: p.expr(1)})
+		} else if len(namedArgs) > 0 && !takesVariadicArgs(function) {
+			p.error("Positional arguments must come before named arguments")
+		} else {
 			args = append(args, p.expr(1))
 		}
 	}
-	return FunctionCall{Function: function, Args: args, NumExpectedReturnValues: numExpectedReturnValues}
+	return FunctionCall{Function: function, Args: args, NamedArgs: namedArgs, NumExpectedReturnValues: numExpectedReturnValues}
+}
+
+// takesVariadicArgs is true if the function's variadic args may follow its named args e.g. filter(vals, function="f", "a")
+func takesVariadicArgs(function Token) bool {
+	signature, ok := GetSignature(function.GetLexeme())
+	return ok && signature.isVariadic()
 }
 
 func (p *Parser) arrayExpr() (Expr, bool) {
@@ -915,6 +1301,11 @@ func (p *Parser) literal(expectedType *RslTypeEnum) (Literal, bool) {
 		return p.boolLiteral(), true
 	}
 
+	// null is not a valid literal for a specific type e.g. an arg default, only as a general value
+	if expectedType == nil && p.matchAny(NULL_LITERAL) {
+		return NullLiteral{Value: p.previous()}, true
+	}
+
 	return nil, false
 }
 
@@ -923,6 +1314,47 @@ func (p *Parser) stringLiteral() StringLiteral {
 	return StringLiteral{Value: *literal}
 }
 
+// interpolatedStringLiteral parses a string literal along with the expressions interpolated into it, which are only
+// parsed for strings used as expressions.
+func (p *Parser) interpolatedStringLiteral() StringLiteral {
+	literal := p.stringLiteral()
+	for _, segment := range literal.Value.Segments {
+		if segment.Interpolation != nil {
+			literal.Interpolations = append(literal.Interpolations, p.interpolation(*segment.Interpolation))
+		}
+	}
+	return literal
+}
+
+func (p *Parser) interpolation(tokens InterpolationTokens) Interpolation {
+	escapeHint := "to write a literal brace, escape it e.g. `\\{`"
+	if !tokens.Closed {
+		p.errorAt(tokens.Token, "Unterminated interpolation, expected '}'", escapeHint)
+	}
+	if len(tokens.Expr) == 1 {
+		p.errorAt(tokens.Token, "Expected an expression to interpolate", escapeHint)
+	}
+
+	var format *InterpolationFormat
+	if tokens.Format != nil {
+		parsed, errMsg := parseInterpolationFormat(tokens.Format.GetLexeme())
+		if errMsg != "" {
+			p.errorAt(tokens.Format, errMsg, INTERPOLATION_FORMAT_HINT)
+		}
+		format = &parsed
+	}
+
+	// the interpolation's tokens are parsed in place of the string's, which are restored after
+	outerTokens, outerNext := p.tokens, p.next
+	defer func() { p.tokens, p.next = outerTokens, outerNext }()
+	p.tokens, p.next = tokens.Expr, 0
+	expr := p.expr(1)
+	if !p.isAtEnd() {
+		p.error("Expected '}' after interpolated expression")
+	}
+	return Interpolation{Expr: expr, Format: format, Token: tokens.Token}
+}
+
 func (p *Parser) intLiteral() IntLiteral {
 	literal := p.consume(INT_LITERAL, "Expected int literal").(*IntLiteralToken)
 	return IntLiteral{Value: *literal}
@@ -1114,6 +1546,27 @@ func (p *Parser) rslType() RslType {
 		} else {
 			rslTypeEnum = RslBoolT
 		}
+	} else if p.matchKeyword(DATE, ARGS_BLOCK_KEYWORDS) || p.matchKeyword(DATETIME, ARGS_BLOCK_KEYWORDS) ||
+		p.matchKeyword(DURATION, ARGS_BLOCK_KEYWORDS) {
+		argType = p.previous()
+		switch ARGS_BLOCK_KEYWORDS[argType.GetLexeme()] {
+		case DATE:
+			rslTypeEnum = RslDateT
+		case DATETIME:
+			rslTypeEnum = RslDateTimeT
+		default:
+			rslTypeEnum = RslDurationT
+		}
+		if p.peekType(BRACKETS) {
+			p.error(fmt.Sprintf("Arrays of %s are not supported", rslTypeEnum.AsString()))
+		}
+	} else if p.matchKeyword(PATH, ARGS_BLOCK_KEYWORDS) || p.matchKeyword(DIR, ARGS_BLOCK_KEYWORDS) {
+		// strings, which are completed as paths in a shell
+		argType = p.previous()
+		rslTypeEnum = RslStringT
+		if p.peekType(BRACKETS) {
+			p.error(fmt.Sprintf("Arrays of %s are not supported", argType.GetLexeme()))
+		}
 	} else {
 		p.error("Expected arg type")
 	}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"fmt"
+	"github.com/charmbracelet/huh"
+	"github.com/samber/lo"
+	"strings"
+)
+
+// Picker chooses one of several options for pick(), pick_kv(), and pick_from_resource(), returning its index.
+type Picker interface {
+	Pick(prompt string, options []PickOption) (int, error)
+}
+
+// PickOption is shown by its label, and picked by any of its keys, which are what the user would type for it.
+type PickOption struct {
+	Label string
+	Keys  []string
+}
+
+// pickOptions makes options which are picked by their labels.
+func pickOptions(labels []string) []PickOption {
+	return lo.Map(labels, func(label string, _ int) PickOption {
+		return PickOption{Label: label, Keys: []string{label}}
+	})
+}
+
+// HuhPicker asks the user to pick from a list in the terminal.
+type HuhPicker struct {
+}
+
+func NewHuhPicker() Picker {
+	return &HuhPicker{}
+}
+
+func (h *HuhPicker) Pick(prompt string, options []PickOption) (int, error) {
+	if !isInteractive() {
+		return 0, fmt.Errorf("Cannot pick from %d options without an interactive terminal. "+
+			"Supply selections with --PICK or $RAD_PICK", len(options))
+	}
+
+	huhOptions := make([]huh.Option[int], len(options))
+	for idx, option := range options {
+		huhOptions[idx] = huh.NewOption(option.Label, idx)
+	}
+
+	var result int
+	err := huh.NewSelect[int]().
+		Title(prompt).
+		Options(huhOptions...).
+		Value(&result).
+		Run()
+	return result, err
+}
+
+// ScriptedPicker answers with selections given up front, in order, one per pick. A selection matches the option with
+// a key it equals, or else the only option with a key containing it, ignoring case.
+type ScriptedPicker struct {
+	Selections []string
+}
+
+func NewScriptedPicker(selections ...string) *ScriptedPicker {
+	return &ScriptedPicker{Selections: selections}
+}
+
+func (s *ScriptedPicker) Pick(prompt string, options []PickOption) (int, error) {
+	keys := lo.FlatMap(options, func(option PickOption, _ int) []string { return option.Keys })
+	if len(s.Selections) == 0 {
+		return 0, fmt.Errorf("No selection left to pick from %d options: %q", len(options), keys)
+	}
+	selection := s.Selections[0]
+	s.Selections = s.Selections[1:]
+
+	for idx, option := range options {
+		if lo.Contains(option.Keys, selection) {
+			return idx, nil
+		}
+	}
+
+	var matches []int
+	for idx, option := range options {
+		if lo.SomeBy(option.Keys, func(key string) bool {
+			return strings.Contains(strings.ToLower(key), strings.ToLower(selection))
+		}) {
+			matches = append(matches, idx)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return 0, fmt.Errorf("Selection %q matches none of the options: %q", selection, keys)
+	default:
+		return 0, fmt.Errorf("Selection %q matches %d of the options: %q", selection, len(matches), keys)
+	}
+}
+
+// usePickSelections replaces the picker if selections were supplied with --PICK, or else $RAD_PICK, which separates
+// them with commas.
+func usePickSelections() {
+	selections := pickFlag
+	if len(selections) == 0 {
+		if env, ok := REnvVars.Lookup("RAD_PICK"); ok && env != "" {
+			selections = strings.Split(env, ",")
+		}
+	}
+	if len(selections) > 0 {
+		RPicker = NewScriptedPicker(selections...)
+	}
+}
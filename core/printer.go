@@ -32,9 +32,21 @@ type Printer interface {
 	ErrorExit(msg string)
 
 	// For the parser and interpreter to print errors, with a token.
+	// Printed with an excerpt of the source it's in, set with SetSource.
 	// Exits.
 	TokenErrorExit(token Token, msg string)
 
+	// Like TokenErrorExit, but for errors with notes or hints.
+	// Exits.
+	RslErrorExit(err RslError)
+
+	// Like RslErrorExit, but for several errors found at once e.g. by the parser or type checker.
+	// Exits.
+	TokenErrorsExit(errs []RslError)
+
+	// Sets the path and source of the script being run, for printing excerpts of it with errors.
+	SetSource(path string, source string)
+
 	// For errors not related to the RSL script, but to rad itself and its usage (probably misuse or rad bugs).
 	// Exits.
 	RadErrorExit(msg string)
@@ -79,6 +91,7 @@ type stdPrinter struct {
 	isQuiet       bool
 	isScriptDebug bool
 	isRadDebug    bool
+	source        sourceFile
 }
 
 func (p *stdPrinter) ScriptDebug(msg string) {
@@ -127,23 +140,37 @@ func (p *stdPrinter) ErrorExit(msg string) {
 		fmt.Fprint(p.stdErr, msg)
 	}
 	p.printShellExitIfEnabled()
+	p.exit()
 }
 
 func (p *stdPrinter) TokenErrorExit(token Token, msg string) {
+	if token != nil {
+		p.RslErrorExit(RslError{Token: token, Msg: strings.TrimSuffix(msg, "\n")})
+		return
+	}
 	if !p.isQuiet || p.isScriptDebug {
-		if token == nil {
-			fmt.Fprint(p.stdErr, msg)
-		} else {
-			lexeme := token.GetLexeme()
-			lexeme = strings.ReplaceAll(lexeme, "\n", "\\n")
-			fmt.Fprintf(p.stdErr, "RslError at L%d/%d on '%s': %s",
-				token.GetLine(), token.GetCharLineStart(), token.GetLexeme(), msg)
-		}
+		fmt.Fprint(p.stdErr, msg)
 	}
 	p.printShellExitIfEnabled()
 	p.exit()
 }
 
+func (p *stdPrinter) RslErrorExit(err RslError) {
+	p.TokenErrorsExit([]RslError{err})
+}
+
+func (p *stdPrinter) TokenErrorsExit(errs []RslError) {
+	if !p.isQuiet || p.isScriptDebug {
+		fmt.Fprint(p.stdErr, renderDiagnostics(p.source, SEVERITY_ERROR, errs, shouldColor(p.stdErr)))
+	}
+	p.printShellExitIfEnabled()
+	p.exit()
+}
+
+func (p *stdPrinter) SetSource(path string, source string) {
+	p.source = newSourceFile(path, source)
+}
+
 func (p *stdPrinter) RadErrorExit(msg string) {
 	fmt.Fprint(p.stdErr, msg)
 	p.printShellExitIfEnabled()
@@ -2,12 +2,14 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"regexp"
+	"strings"
 )
 
 type Requester struct {
@@ -24,22 +26,29 @@ func (r *Requester) AddMockedResponse(urlRegex string, jsonPath string) {
 	r.jsonPathsByMockedUrlRegex[urlRegex] = jsonPath
 }
 
-func (r *Requester) Request(url string) (string, error) {
-	mockJson, ok := r.resolveMockedResponse(url)
+// Request gets the url, which may be a secret. This is the only place secrets are revealed, and the url is still
+// printed redacted.
+func (r *Requester) Request(rawUrl interface{}) (string, error) {
+	url, shownUrl := revealForRequest(rawUrl)
+	mockJson, ok := r.resolveMockedResponse(url, shownUrl)
 	if ok {
 		return mockJson, nil
 	}
 
 	urlToQuery, err := encodeUrl(url)
 	if err != nil {
-		return "", err
+		return "", hideUrl(err, url, shownUrl)
 	}
 
-	RP.RadInfo(fmt.Sprintf("Querying url: %s\n", urlToQuery))
+	shownUrlToQuery, err := encodeUrl(shownUrl)
+	if err != nil {
+		shownUrlToQuery = shownUrl
+	}
+	RP.RadInfo(fmt.Sprintf("Querying url: %s\n", shownUrlToQuery))
 
 	resp, err := http.Get(urlToQuery)
 	if err != nil {
-		return "", fmt.Errorf("error making HTTP request: %w", err)
+		return "", hideUrl(fmt.Errorf("error making HTTP request: %w", err), urlToQuery, shownUrlToQuery)
 	}
 	defer resp.Body.Close()
 
@@ -51,7 +60,7 @@ func (r *Requester) Request(url string) (string, error) {
 	return string(body), nil
 }
 
-func (r *Requester) RequestJson(url string) (interface{}, error) {
+func (r *Requester) RequestJson(url interface{}) (interface{}, error) {
 	body, err := r.Request(url)
 	if err != nil {
 		return nil, err
@@ -70,6 +79,14 @@ func (r *Requester) RequestJson(url string) (interface{}, error) {
 	return data, nil
 }
 
+// hideUrl replaces the url in the error with how it's shown, in case it holds secrets.
+func hideUrl(err error, url string, shownUrl string) error {
+	if url == shownUrl {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), url, shownUrl))
+}
+
 // todo test this more, might need additional query param encoding
 func encodeUrl(rawUrl string) (string, error) {
 	parsedUrl, err := url.Parse(rawUrl)
@@ -79,7 +96,7 @@ func encodeUrl(rawUrl string) (string, error) {
 	return parsedUrl.String(), nil
 }
 
-func (r *Requester) resolveMockedResponse(url string) (string, bool) {
+func (r *Requester) resolveMockedResponse(url string, shownUrl string) (string, bool) {
 	for urlRegex, jsonPath := range r.jsonPathsByMockedUrlRegex {
 		re, err := regexp.Compile(urlRegex)
 		if err != nil {
@@ -87,11 +104,11 @@ func (r *Requester) resolveMockedResponse(url string) (string, bool) {
 		}
 
 		if re.MatchString(url) {
-			RP.RadInfo(fmt.Sprintf("Mocking response for url (matched %q): %s\n", urlRegex, url))
+			RP.RadInfo(fmt.Sprintf("Mocking response for url (matched %q): %s\n", urlRegex, shownUrl))
 			data := r.loadMockedResponse(jsonPath)
 			return data, true
 		} else {
-			RP.RadDebug(fmt.Sprintf("No match for url %q against regex %q", url, urlRegex))
+			RP.RadDebug(fmt.Sprintf("No match for url %q against regex %q", shownUrl, urlRegex))
 		}
 	}
 	return "", false
@@ -27,17 +27,9 @@ type PickResourceOptionSerde struct {
 }
 
 func LoadPickResource(i *MainInterpreter, function Token, jsonPath string, numExpectedReturnValues int) PickResource {
-	finalPath := resolveFinalPath(jsonPath)
-	file, err := os.Open(finalPath)
+	resource, err := readPickResource(jsonPath)
 	if err != nil {
-		i.error(function, fmt.Sprintf("Error opening file: %s", err))
-	}
-	defer file.Close()
-
-	resource := PickResourceSerde{}
-	decoder := json.NewDecoder(file)
-	if err = decoder.Decode(&resource); err != nil {
-		i.error(function, fmt.Sprintf("Error decoding JSON into pick resource: %s", err))
+		i.error(function, err.Error())
 	}
 
 	var opts []PickResourceOpt
@@ -65,6 +57,22 @@ func LoadPickResource(i *MainInterpreter, function Token, jsonPath string, numEx
 	}
 }
 
+func readPickResource(jsonPath string) (PickResourceSerde, error) {
+	finalPath := resolveFinalPath(jsonPath)
+	file, err := os.Open(finalPath)
+	if err != nil {
+		return PickResourceSerde{}, fmt.Errorf("Error opening file: %s", err)
+	}
+	defer file.Close()
+
+	resource := PickResourceSerde{}
+	decoder := json.NewDecoder(file)
+	if err = decoder.Decode(&resource); err != nil {
+		return PickResourceSerde{}, fmt.Errorf("Error decoding JSON into pick resource: %s", err)
+	}
+	return resource, nil
+}
+
 func resolveFinalPath(pathFromRslScript string) string {
 	if filepath.IsAbs(pathFromRslScript) {
 		return pathFromRslScript
@@ -0,0 +1,412 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Array functions work across typed and mixed arrays alike. Those which only rearrange or drop values return the same
+// type of array they were given.
+//
+// RSL has no function values, so functions are passed by name e.g. map(names, "upper"). Keys, for sorting and
+// grouping, are either such a name, or an int to index into each value, for arrays of arrays e.g. rows.
+
+// runSort sorts ascending, or descending if the direction is "desc". Values with equal keys keep their order.
+func runSort(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	values, _ := ToMixedArray(args[0])
+	var key interface{}
+	if len(args) > 1 {
+		key = args[1]
+	}
+	descending := false
+	if len(args) > 2 {
+		descending = isDescending(i, function, args[2].(string))
+	}
+
+	keys := keysOf(i, function, values, key)
+	order := make([]int, len(values))
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		comparison := compareValues(i, function, keys[order[a]], keys[order[b]])
+		if descending {
+			return comparison > 0
+		}
+		return comparison < 0
+	})
+
+	sorted := make([]interface{}, len(values))
+	for idx, original := range order {
+		sorted[idx] = values[original]
+	}
+	return AsArrayLike(args[0], sorted)
+}
+
+func isDescending(i *MainInterpreter, function Token, direction string) bool {
+	switch strings.ToLower(direction) {
+	case "asc":
+		return false
+	case "desc":
+		return true
+	default:
+		i.errorWithHint(function, fmt.Sprintf("%s() direction must be \"asc\" or \"desc\", got %q",
+			function.GetLexeme(), direction), didYouMean(direction, []string{"asc", "desc"}))
+		panic(UNREACHABLE)
+	}
+}
+
+// runReverse reverses an array, or the characters of a string.
+func runReverse(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	if str, ok := args[0].(string); ok {
+		runes := []rune(str)
+		for a, b := 0, len(runes)-1; a < b; a, b = a+1, b-1 {
+			runes[a], runes[b] = runes[b], runes[a]
+		}
+		return string(runes)
+	}
+
+	values, _ := ToMixedArray(args[0])
+	reversed := make([]interface{}, len(values))
+	for idx, value := range values {
+		reversed[len(values)-1-idx] = value
+	}
+	return AsArrayLike(args[0], reversed)
+}
+
+// runUniq drops values equal to an earlier one.
+func runUniq(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	values, _ := ToMixedArray(args[0])
+	seen := make(map[string]bool)
+	unique := make([]interface{}, 0)
+	for _, value := range values {
+		key := valueKey(value)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, value)
+		}
+	}
+	return AsArrayLike(args[0], unique)
+}
+
+// runFilter keeps the truthy values, or those for which the named function returns something truthy. Extra args are
+// passed to the function after the value e.g. filter(names, "starts_with", "a").
+func runFilter(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	values, _ := ToMixedArray(args[0])
+	kept := make([]interface{}, 0)
+	for _, value := range values {
+		result := value
+		if len(args) > 1 {
+			result = callFunctionByName(i, function, args[1].(string), append([]interface{}{value}, args[2:]...))
+		}
+		if IsTruthy(result) {
+			kept = append(kept, value)
+		}
+	}
+	return AsArrayLike(args[0], kept)
+}
+
+// runMap calls the named function on each value, with any extra args after it e.g. map(names, "pad_left", 10).
+func runMap(i *MainInterpreter, function Token, args []interface{}) []interface{} {
+	values, _ := ToMixedArray(args[0])
+	name := args[1].(string)
+	mapped := make([]interface{}, len(values))
+	for idx, value := range values {
+		mapped[idx] = callFunctionByName(i, function, name, append([]interface{}{value}, args[2:]...))
+	}
+	return mapped
+}
+
+// runZip pairs up the arrays' values by index, stopping at the end of the shortest array.
+func runZip(i *MainInterpreter, function Token, args []interface{}) []interface{} {
+	arrays := make([][]interface{}, len(args))
+	length := 0
+	for idx, arg := range args {
+		arrays[idx], _ = ToMixedArray(arg)
+		if idx == 0 || len(arrays[idx]) < length {
+			length = len(arrays[idx])
+		}
+	}
+
+	zipped := make([]interface{}, length)
+	for idx := range zipped {
+		tuple := make([]interface{}, len(arrays))
+		for arrIdx, array := range arrays {
+			tuple[arrIdx] = array[idx]
+		}
+		zipped[idx] = tuple
+	}
+	return zipped
+}
+
+// runFlatten replaces nested arrays with their values, going as many levels deep as the depth.
+func runFlatten(i *MainInterpreter, function Token, args []interface{}) []interface{} {
+	values, _ := ToMixedArray(args[0])
+	depth := int64(1)
+	if len(args) > 1 {
+		depth = args[1].(int64)
+	}
+	if depth < 0 {
+		i.error(function, fmt.Sprintf("flatten() depth must not be negative, got %d", depth))
+	}
+	return flatten(values, depth)
+}
+
+func flatten(values []interface{}, depth int64) []interface{} {
+	flattened := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		if nested, ok := ToMixedArray(value); ok && depth > 0 {
+			flattened = append(flattened, flatten(nested, depth-1)...)
+		} else {
+			flattened = append(flattened, value)
+		}
+	}
+	return flattened
+}
+
+// runChunk splits the values into arrays of the given size, the last of which may be smaller.
+func runChunk(i *MainInterpreter, function Token, args []interface{}) []interface{} {
+	values, _ := ToMixedArray(args[0])
+	size := args[1].(int64)
+	if size <= 0 {
+		i.error(function, fmt.Sprintf("chunk() size must be positive, got %d", size))
+	}
+
+	chunks := make([]interface{}, 0)
+	for start := 0; start < len(values); start += int(size) {
+		end := min(start+int(size), len(values))
+		chunks = append(chunks, AsArrayLike(args[0], values[start:end]))
+	}
+	return chunks
+}
+
+// runSum adds up the numbers, giving an int if they're all ints, or else a float.
+func runSum(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	values, _ := ToMixedArray(args[0])
+	intSum := int64(0)
+	floatSum := 0.0
+	isFloat := false
+	for _, value := range values {
+		switch number := value.(type) {
+		case int64:
+			intSum += number
+		case float64:
+			floatSum += number
+			isFloat = true
+		default:
+			notANumber(i, function, value)
+		}
+	}
+
+	if isFloat {
+		return floatSum + float64(intSum)
+	}
+	return intSum
+}
+
+func runAvg(i *MainInterpreter, function Token, args []interface{}) float64 {
+	values := nonEmptyArrayArg(i, function, args[0])
+	total := 0.0
+	for _, value := range values {
+		switch number := value.(type) {
+		case int64:
+			total += float64(number)
+		case float64:
+			total += number
+		default:
+			notANumber(i, function, value)
+		}
+	}
+	return total / float64(len(values))
+}
+
+func notANumber(i *MainInterpreter, function Token, value interface{}) {
+	i.error(function, fmt.Sprintf("%s() takes an array of numbers, but it contains %s",
+		function.GetLexeme(), describeTypes([]RslTypeEnum{NewRuntimeLiteral(value).Type})))
+}
+
+// runMinMax returns the smallest value for min(), or the largest for max(). Works for anything comparable, such as
+// numbers, strings, and dates.
+func runMinMax(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	values := nonEmptyArrayArg(i, function, args[0])
+	result := values[0]
+	for _, value := range values[1:] {
+		comparison := compareValues(i, function, value, result)
+		if (function.GetLexeme() == "min" && comparison < 0) || (function.GetLexeme() == "max" && comparison > 0) {
+			result = value
+		}
+	}
+	return result
+}
+
+func nonEmptyArrayArg(i *MainInterpreter, function Token, arg interface{}) []interface{} {
+	values, _ := ToMixedArray(arg)
+	if len(values) == 0 {
+		i.error(function, fmt.Sprintf("%s() needs a non-empty array", function.GetLexeme()))
+	}
+	return values
+}
+
+// runCountBy counts the values with each key, returning [key, count] pairs in order of each key's first appearance.
+// Without a key, equal values are counted.
+func runCountBy(i *MainInterpreter, function Token, args []interface{}) []interface{} {
+	values, _ := ToMixedArray(args[0])
+	keys, groups := groupByKey(i, function, values, args[1:])
+	counts := make([]interface{}, len(keys))
+	for idx, key := range keys {
+		counts[idx] = []interface{}{key, int64(len(groups[idx]))}
+	}
+	return counts
+}
+
+// runGroupBy groups the values by key, returning [key, values] pairs in order of each key's first appearance.
+func runGroupBy(i *MainInterpreter, function Token, args []interface{}) []interface{} {
+	values, _ := ToMixedArray(args[0])
+	keys, groups := groupByKey(i, function, values, args[1:])
+	grouped := make([]interface{}, len(keys))
+	for idx, key := range keys {
+		grouped[idx] = []interface{}{key, AsArrayLike(args[0], groups[idx])}
+	}
+	return grouped
+}
+
+func groupByKey(i *MainInterpreter, function Token, values []interface{}, keyArg []interface{}) ([]interface{}, [][]interface{}) {
+	var key interface{}
+	if len(keyArg) > 0 {
+		key = keyArg[0]
+	}
+
+	var keys []interface{}
+	var groups [][]interface{}
+	groupIdxs := make(map[string]int)
+	for idx, groupKey := range keysOf(i, function, values, key) {
+		groupIdx, ok := groupIdxs[valueKey(groupKey)]
+		if !ok {
+			groupIdx = len(keys)
+			groupIdxs[valueKey(groupKey)] = groupIdx
+			keys = append(keys, groupKey)
+			groups = append(groups, nil)
+		}
+		groups[groupIdx] = append(groups[groupIdx], values[idx])
+	}
+	return keys, groups
+}
+
+// arrayIndexOf returns the index of the first value equal to the target, or -1 if there is none.
+func arrayIndexOf(values []interface{}, target interface{}) int64 {
+	targetKey := valueKey(target)
+	for idx, value := range values {
+		if valueKey(value) == targetKey {
+			return int64(idx)
+		}
+	}
+	return -1
+}
+
+// keysOf returns the key for each value: the value itself if there's no key, the value's element at the key's index
+// if it's an int, or else what the function named by the key returns for the value.
+func keysOf(i *MainInterpreter, function Token, values []interface{}, key interface{}) []interface{} {
+	keys := make([]interface{}, len(values))
+	for idx, value := range values {
+		switch coerced := key.(type) {
+		case nil:
+			keys[idx] = value
+		case int64:
+			element, ok := ToMixedArray(value)
+			if !ok {
+				i.error(function, fmt.Sprintf("%s() key %d indexes into arrays, but got %s",
+					function.GetLexeme(), coerced, describeTypes([]RslTypeEnum{NewRuntimeLiteral(value).Type})))
+			}
+			if coerced < 0 || coerced >= int64(len(element)) {
+				i.error(function, fmt.Sprintf("%s() key %d is out of bounds for %s (length %d)",
+					function.GetLexeme(), coerced, ToPrintable(value), len(element)))
+			}
+			keys[idx] = element[coerced]
+		case string:
+			keys[idx] = callFunctionByName(i, function, coerced, []interface{}{value})
+		}
+	}
+	return keys
+}
+
+// valueKey is a string identifying the value, equal for values which are equal, so values can be looked up by it.
+// Ints and floats are compared as numbers, so 1 and 1.0 are the same.
+func valueKey(value interface{}) string {
+	switch coerced := value.(type) {
+	case int64, float64:
+		return "n" + ToPrintable(coerced)
+	case string:
+		return "s" + strconv.Quote(coerced)
+	}
+	if array, ok := ToMixedArray(value); ok {
+		keys := make([]string, len(array))
+		for idx, element := range array {
+			keys[idx] = valueKey(element)
+		}
+		return "[" + strings.Join(keys, ",") + "]"
+	}
+	return NewRuntimeLiteral(value).Type.AsString() + ToPrintable(value)
+}
+
+// compareValues orders two values, returning a negative number if a comes first, positive if b does, or 0 if they're
+// tied. Nulls come before everything else.
+func compareValues(i *MainInterpreter, function Token, a interface{}, b interface{}) int {
+	if a == nil || b == nil {
+		switch {
+		case a == b:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	switch left := a.(type) {
+	case int64:
+		switch right := b.(type) {
+		case int64:
+			return cmp.Compare(left, right)
+		case float64:
+			return cmp.Compare(float64(left), right)
+		}
+	case float64:
+		switch right := b.(type) {
+		case int64:
+			return cmp.Compare(left, float64(right))
+		case float64:
+			return cmp.Compare(left, right)
+		}
+	case string:
+		if right, ok := b.(string); ok {
+			return strings.Compare(left, right)
+		}
+	case bool:
+		if right, ok := b.(bool); ok {
+			switch {
+			case left == right:
+				return 0
+			case !left:
+				return -1
+			default:
+				return 1
+			}
+		}
+	case RslDuration:
+		if right, ok := b.(RslDuration); ok {
+			return cmp.Compare(left, right)
+		}
+	case RslDate, RslDateTime:
+		switch b.(type) {
+		case RslDate, RslDateTime:
+			return timeArg(i, function, a).Compare(timeArg(i, function, b))
+		}
+	}
+
+	i.error(function, fmt.Sprintf("%s() cannot compare %s with %s", function.GetLexeme(),
+		describeTypes([]RslTypeEnum{NewRuntimeLiteral(a).Type}), describeTypes([]RslTypeEnum{NewRuntimeLiteral(b).Type})))
+	panic(UNREACHABLE)
+}
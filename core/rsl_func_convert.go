@@ -0,0 +1,89 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// runInt converts to an int. Floats are truncated toward zero, and strings must hold a whole number.
+func runInt(i *MainInterpreter, function Token, args []interface{}) int64 {
+	switch value := args[0].(type) {
+	case int64:
+		return value
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) || math.Abs(value) >= math.MaxInt64 {
+			i.error(function, fmt.Sprintf("int() cannot convert %s to an int", ToPrintable(value)))
+		}
+		return int64(value)
+	case bool:
+		if value {
+			return 1
+		}
+		return 0
+	case string:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			hint := ""
+			if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				hint = fmt.Sprintf("to truncate it, convert it to a float first e.g. `int(float(%q))`", value)
+			}
+			i.errorWithHint(function, fmt.Sprintf("int() cannot convert %q to an int", value), hint)
+		}
+		return parsed
+	default:
+		cannotConvert(i, function, value, "an int")
+		panic(UNREACHABLE)
+	}
+}
+
+func runFloat(i *MainInterpreter, function Token, args []interface{}) float64 {
+	switch value := args[0].(type) {
+	case float64:
+		return value
+	case int64:
+		return float64(value)
+	case bool:
+		if value {
+			return 1
+		}
+		return 0
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			i.error(function, fmt.Sprintf("float() cannot convert %q to a float", value))
+		}
+		return parsed
+	default:
+		cannotConvert(i, function, value, "a float")
+		panic(UNREACHABLE)
+	}
+}
+
+// runBool converts to a bool. Strings are parsed e.g. "true", "no", "1", other values by whether they're truthy.
+func runBool(i *MainInterpreter, function Token, args []interface{}) bool {
+	value, ok := args[0].(string)
+	if !ok {
+		return IsTruthy(args[0])
+	}
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "t", "yes", "y", "1":
+		return true
+	case "false", "f", "no", "n", "0", "":
+		return false
+	default:
+		i.error(function, fmt.Sprintf("bool() cannot convert %q to a bool", value))
+		panic(UNREACHABLE)
+	}
+}
+
+func runTypeOf(i *MainInterpreter, function Token, args []interface{}) string {
+	return NewRuntimeLiteral(args[0]).Type.AsString()
+}
+
+func cannotConvert(i *MainInterpreter, function Token, value interface{}, to string) {
+	i.error(function, fmt.Sprintf("%s() cannot convert %s to %s",
+		function.GetLexeme(), describeTypes([]RslTypeEnum{NewRuntimeLiteral(value).Type}), to))
+}
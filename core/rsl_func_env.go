@@ -0,0 +1,43 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// runEnv returns the environment variable, or the default if it's not set. Without a default, returns null.
+func runEnv(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	name := args[0].(string)
+	if value, ok := REnvVars.Lookup(name); ok {
+		return value
+	}
+	if len(args) == 2 {
+		return args[1]
+	}
+	return nil
+}
+
+func runEnvRequired(i *MainInterpreter, function Token, args []interface{}) string {
+	name := args[0].(string)
+	value, ok := REnvVars.Lookup(name)
+	if !ok {
+		hint := didYouMean(name, REnvVars.Names())
+		if hint == "" {
+			hint = fmt.Sprintf("set it before running the script e.g. `export %s=...`", name)
+		}
+		i.errorWithHint(function, fmt.Sprintf("Environment variable '%s' is required, but is not set", name), hint)
+	}
+	return value
+}
+
+// runSetEnv sets an environment variable for any child processes the script runs.
+func runSetEnv(i *MainInterpreter, function Token, args []interface{}) {
+	name := args[0].(string)
+	if name == "" || strings.Contains(name, "=") {
+		i.error(function, fmt.Sprintf("Invalid environment variable name: %q", name))
+	}
+
+	if err := REnvVars.Set(name, ToPrintable(args[1])); err != nil {
+		i.error(function, fmt.Sprintf("Could not set environment variable '%s': %v", name, err))
+	}
+}
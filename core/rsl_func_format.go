@@ -0,0 +1,95 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// runFormat formats values printf-style e.g. format("%-10s|%6.2f", name, price). Each verb is checked against its
+// value, so mistakes are reported rather than printed as Go's %!d(string=...) markers.
+func runFormat(i *MainInterpreter, function Token, args []interface{}) string {
+	template := args[0].(string)
+	values := args[1:]
+
+	var converted []interface{}
+	runes := []rune(template)
+	for idx := 0; idx < len(runes); idx++ {
+		if runes[idx] != '%' {
+			continue
+		}
+
+		start := idx
+		idx++
+		for idx < len(runes) && strings.ContainsRune("+-# 0123456789.", runes[idx]) {
+			idx++
+		}
+		if idx >= len(runes) {
+			i.error(function, fmt.Sprintf("format() has an incomplete verb at the end: %q", string(runes[start:])))
+		}
+
+		verb := runes[idx]
+		if verb == '%' {
+			if idx != start+1 {
+				i.error(function, fmt.Sprintf("format() does not allow flags on %%%%, got %q", string(runes[start:idx+1])))
+			}
+			continue
+		}
+
+		if len(converted) >= len(values) {
+			i.error(function, fmt.Sprintf("format() has more verbs than values: %q needs at least %d, got %d",
+				template, len(converted)+1, len(values)))
+		}
+		value, errMsg := formatValue(verb, values[len(converted)])
+		if errMsg != "" {
+			i.error(function, fmt.Sprintf("format() %s for %q", errMsg, string(runes[start:idx+1])))
+		}
+		converted = append(converted, value)
+	}
+
+	if len(converted) < len(values) {
+		i.error(function, fmt.Sprintf("format() has more values than verbs: %q uses %d, got %d",
+			template, len(converted), len(values)))
+	}
+	return fmt.Sprintf(template, converted...)
+}
+
+// formatValue converts an RSL value into what Go's fmt expects for the verb, or returns a message explaining why
+// the value can't be formatted with it.
+func formatValue(verb rune, value interface{}) (interface{}, string) {
+	typeName := NewRuntimeLiteral(value).Type.AsString()
+	switch verb {
+	case 'v', 's':
+		return ToPrintable(value), ""
+	case 'q':
+		if str, ok := value.(string); ok {
+			return str, ""
+		}
+		return nil, "expects a string, got " + typeName
+	case 'd', 'b', 'o', 'c', 'U':
+		if n, ok := value.(int64); ok {
+			return n, ""
+		}
+		return nil, "expects an int, got " + typeName
+	case 'x', 'X':
+		switch value.(type) {
+		case int64, float64, string:
+			return value, ""
+		}
+		return nil, "expects an int, float, or string, got " + typeName
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		switch coerced := value.(type) {
+		case float64:
+			return coerced, ""
+		case int64:
+			return float64(coerced), ""
+		}
+		return nil, "expects a float or int, got " + typeName
+	case 't':
+		if b, ok := value.(bool); ok {
+			return b, ""
+		}
+		return nil, "expects a bool, got " + typeName
+	default:
+		return nil, fmt.Sprintf("does not support the verb %%%c", verb)
+	}
+}
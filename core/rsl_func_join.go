@@ -3,10 +3,6 @@ package core
 import "strings"
 
 func RunJoin(i *MainInterpreter, function Token, values []interface{}) interface{} {
-	if len(values) < 2 {
-		i.error(function, "join() takes at least two arguments")
-	}
-
 	prefix := ""
 	suffix := ""
 	if len(values) == 3 {
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RslJsonObject is a JSON object read by parse_json() or a rad block's json paths. RSL has no type for objects, so to
+// scripts it's a string holding the object's compact JSON, but to_json() writes it back out as an object.
+type RslJsonObject struct {
+	text string
+}
+
+func (o RslJsonObject) String() string {
+	return o.text
+}
+
+// jsonObjectAsText gives the text of a JSON object, for where it's used as the string it is to scripts.
+func jsonObjectAsText(value interface{}) interface{} {
+	if obj, ok := value.(RslJsonObject); ok {
+		return obj.text
+	}
+	return value
+}
+
+// jsonObjectsAsText replaces any JSON objects in the value, including in arrays, with their text. Returns whether there
+// were any, leaving the value as it is if not.
+func jsonObjectsAsText(value interface{}) (interface{}, bool) {
+	switch coerced := value.(type) {
+	case RslJsonObject:
+		return coerced.text, true
+	case []interface{}:
+		replaced := make([]interface{}, len(coerced))
+		found := false
+		for idx, elem := range coerced {
+			var elemFound bool
+			replaced[idx], elemFound = jsonObjectsAsText(elem)
+			found = found || elemFound
+		}
+		if !found {
+			return value, false
+		}
+		return replaced, true
+	default:
+		return value, false
+	}
+}
+
+// runParseJson parses a JSON blob into RSL values. Objects, which RSL has no type for, are strings of compact JSON to
+// scripts, as they are when captured by a rad block. A dot-separated path e.g. "items.0.name" picks a value out of the blob.
+func runParseJson(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	decoder := json.NewDecoder(strings.NewReader(args[0].(string)))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		i.error(function, fmt.Sprintf("parse_json() got invalid JSON: %v", err))
+	}
+	if decoder.More() {
+		i.error(function, "parse_json() got invalid JSON: unexpected content after the value")
+	}
+
+	if len(args) > 1 && args[1].(string) != "" {
+		path := args[1].(string)
+		for _, segment := range strings.Split(path, ".") {
+			data = jsonPathSegment(i, function, path, data, segment)
+		}
+	}
+	return fromJson(i, function, data)
+}
+
+func jsonPathSegment(i *MainInterpreter, function Token, path string, data interface{}, segment string) interface{} {
+	switch coerced := data.(type) {
+	case map[string]interface{}:
+		value, ok := coerced[segment]
+		if !ok {
+			keys := make([]string, 0, len(coerced))
+			for key := range coerced {
+				keys = append(keys, key)
+			}
+			i.errorWithHint(function, fmt.Sprintf("parse_json() path %q: no key %q", path, segment),
+				didYouMean(segment, keys))
+		}
+		return value
+	case []interface{}:
+		idx, err := strconv.Atoi(segment)
+		if err != nil {
+			i.error(function, fmt.Sprintf("parse_json() path %q: %q is not an index into an array", path, segment))
+		}
+		if idx < 0 || idx >= len(coerced) {
+			i.error(function, fmt.Sprintf("parse_json() path %q: index %d out of bounds (length %d)",
+				path, idx, len(coerced)))
+		}
+		return coerced[idx]
+	default:
+		i.error(function, fmt.Sprintf("parse_json() path %q: cannot look up %q in %s", path, segment, jsonString(data)))
+		panic(UNREACHABLE)
+	}
+}
+
+func fromJson(i *MainInterpreter, function Token, data interface{}) interface{} {
+	switch coerced := data.(type) {
+	case json.Number:
+		if n, err := coerced.Int64(); err == nil {
+			return n
+		}
+		f, err := coerced.Float64()
+		if err != nil {
+			i.error(function, fmt.Sprintf("parse_json() cannot represent the number %s", coerced))
+		}
+		return f
+	case []interface{}:
+		values := make([]interface{}, len(coerced))
+		for idx, value := range coerced {
+			values[idx] = fromJson(i, function, value)
+		}
+		return values
+	case map[string]interface{}:
+		return RslJsonObject{text: jsonString(coerced)}
+	default:
+		// strings, bools, and null
+		return coerced
+	}
+}
+
+// runToJson writes a value as JSON, compact unless given a number of spaces to indent with. Objects read from JSON, such
+// as those captured by rad blocks, are written as objects, while other strings stay strings, whatever text they hold.
+func runToJson(i *MainInterpreter, function Token, args []interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if len(args) > 1 {
+		indent := args[1].(int64)
+		if indent < 0 {
+			i.error(function, fmt.Sprintf("to_json() indent must not be negative, got %d", indent))
+		}
+		if indent > 0 {
+			encoder.SetIndent("", strings.Repeat(" ", int(indent)))
+		}
+	}
+
+	if err := encoder.Encode(toJson(args[0])); err != nil {
+		i.error(function, fmt.Sprintf("to_json() could not write JSON: %v", err))
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func toJson(value interface{}) interface{} {
+	if obj, ok := value.(RslJsonObject); ok {
+		return json.RawMessage(obj.text)
+	}
+	if array, ok := ToMixedArray(value); ok {
+		values := make([]interface{}, len(array))
+		for idx, element := range array {
+			values[idx] = toJson(element)
+		}
+		return values
+	}
+	switch value.(type) {
+	case RslDate, RslDateTime, RslDuration:
+		return ToPrintable(value)
+	default:
+		return value
+	}
+}
+
+// jsonString writes decoded JSON back out compactly.
+func jsonString(data interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(data)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"fmt"
+	"math"
+)
+
+// Math functions, like arithmetic operators, give an int if all their number args are ints, or else a float, except
+// where the result is inherently fractional e.g. sqrt().
+
+func runAbs(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	switch value := args[0].(type) {
+	case int64:
+		if value < 0 {
+			return -value
+		}
+		return value
+	default:
+		return math.Abs(asFloat(value))
+	}
+}
+
+// runRound rounds half away from zero to an int, or if a precision is given, to a float with that many decimal
+// places. A negative precision rounds to tens, hundreds, etc.
+func runRound(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	value := asFloat(args[0])
+	if len(args) == 1 {
+		return int64(math.Round(value))
+	}
+
+	scale := math.Pow(10, float64(args[1].(int64)))
+	return math.Round(value*scale) / scale
+}
+
+// runFloorCeil rounds down for floor(), or up for ceil(), to an int.
+func runFloorCeil(i *MainInterpreter, function Token, args []interface{}) int64 {
+	if value, ok := args[0].(int64); ok {
+		return value
+	}
+	if function.GetLexeme() == "floor" {
+		return int64(math.Floor(asFloat(args[0])))
+	}
+	return int64(math.Ceil(asFloat(args[0])))
+}
+
+func runSqrt(i *MainInterpreter, function Token, args []interface{}) float64 {
+	value := asFloat(args[0])
+	if value < 0 {
+		i.error(function, fmt.Sprintf("sqrt() of a negative number: %s", ToPrintable(args[0])))
+	}
+	return math.Sqrt(value)
+}
+
+// runClamp limits the value to between the low and high bounds, inclusive.
+func runClamp(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	value, low, high := args[0], args[1], args[2]
+	if asFloat(low) > asFloat(high) {
+		i.error(function, fmt.Sprintf("clamp() low bound %s is greater than high bound %s",
+			ToPrintable(low), ToPrintable(high)))
+	}
+
+	result := value
+	if asFloat(value) < asFloat(low) {
+		result = low
+	} else if asFloat(value) > asFloat(high) {
+		result = high
+	}
+
+	_, valueIsInt := value.(int64)
+	_, lowIsInt := low.(int64)
+	_, highIsInt := high.(int64)
+	if valueIsInt && lowIsInt && highIsInt {
+		return result
+	}
+	return asFloat(result)
+}
+
+// runPercent returns what percent the part is of the total, optionally rounded to a number of decimal places.
+func runPercent(i *MainInterpreter, function Token, args []interface{}) float64 {
+	total := asFloat(args[1])
+	if total == 0 {
+		i.error(function, "percent() total must not be zero")
+	}
+
+	result := asFloat(args[0]) / total * 100
+	if len(args) == 3 {
+		scale := math.Pow(10, float64(args[2].(int64)))
+		result = math.Round(result*scale) / scale
+	}
+	return result
+}
+
+// power raises the base to the exponent, for both ** and pow(). Ints stay ints, so an int raised to a negative int,
+// which is fractional, is an error rather than quietly becoming a float.
+func power(i *MainInterpreter, token Token, base interface{}, exponent interface{}) interface{} {
+	intBase, baseIsInt := base.(int64)
+	intExponent, exponentIsInt := exponent.(int64)
+
+	if baseIsInt && exponentIsInt {
+		if intExponent < 0 {
+			i.errorWithHint(token, fmt.Sprintf("%d ** %d is not an int, as the exponent is negative", intBase, intExponent),
+				fmt.Sprintf("use a float to allow it e.g. `%d.0 ** %d`", intBase, intExponent))
+		}
+		if math.Abs(math.Pow(float64(intBase), float64(intExponent))) >= math.MaxInt64 {
+			i.errorWithHint(token, fmt.Sprintf("%d ** %d is too large for an int", intBase, intExponent),
+				fmt.Sprintf("use a float to allow it e.g. `%d.0 ** %d`", intBase, intExponent))
+		}
+		result := int64(1)
+		for b, e := intBase, intExponent; e > 0; e >>= 1 {
+			if e&1 == 1 {
+				result *= b
+			}
+			b *= b
+		}
+		return result
+	}
+
+	result := math.Pow(asFloat(base), asFloat(exponent))
+	if math.IsNaN(result) {
+		i.error(token, fmt.Sprintf("%s ** %s is not a real number", ToPrintable(base), ToPrintable(exponent)))
+	}
+	return result
+}
+
+func runPow(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	return power(i, function, args[0], args[1])
+}
+
+// runIdiv divides to an int, dropping any remainder as / does for two ints e.g. idiv(7.5, 2) is 3, and idiv(-7, 2) is -3.
+func runIdiv(i *MainInterpreter, function Token, args []interface{}) int64 {
+	if isZero(args[1]) {
+		i.error(function, "Division by zero")
+	}
+
+	dividend, dividendIsInt := args[0].(int64)
+	divisor, divisorIsInt := args[1].(int64)
+	if dividendIsInt && divisorIsInt {
+		return dividend / divisor
+	}
+
+	result := math.Trunc(asFloat(args[0]) / asFloat(args[1]))
+	if math.IsNaN(result) || math.Abs(result) >= math.MaxInt64 {
+		i.error(function, fmt.Sprintf("idiv(%s, %s) is too large for an int", ToPrintable(args[0]), ToPrintable(args[1])))
+	}
+	return int64(result)
+}
+
+func asFloat(number interface{}) float64 {
+	switch coerced := number.(type) {
+	case int64:
+		return float64(coerced)
+	case float64:
+		return coerced
+	default:
+		panic(UNREACHABLE)
+	}
+}
@@ -2,68 +2,58 @@ package core
 
 import (
 	"fmt"
-	"github.com/charmbracelet/huh"
 	"github.com/lithammer/fuzzysearch/fuzzy"
 )
 
 func runPick(i *MainInterpreter, function Token, values []interface{}) interface{} {
-	numArgs := len(values)
-	if numArgs < 1 {
-		i.error(function, "pick() takes at least one argument")
-	}
-
 	filters := make([]string, 0)
-	switch numArgs {
-	case 1:
-		// no filters, leave it empty
-	case 2:
-		filter := values[1]
-		switch filter.(type) {
-		case string, int64, float64, bool:
-			filters = append(filters, ToPrintable(filter))
-		case []string:
-			filters = filter.([]string)
-		case []interface{}:
-			strings, ok := AsStringArray(filter.([]interface{}))
-			if !ok {
-				i.error(function, "pick() does not allow non-string arrays as filters")
-			}
-			filters = strings
-		default:
-			i.error(function, "pick() does not allow non-string arrays as filters")
-		}
-	default:
-		i.error(function, fmt.Sprintf("pick() takes at most two arguments, got %v", numArgs))
+	if len(values) > 1 {
+		filters = filterArg(i, function, values[1])
+	}
+	prompt := ""
+	if len(values) > 2 {
+		prompt = values[2].(string)
 	}
 
 	switch options := values[0].(type) {
 	case []string:
-		// todo prompt should be a named/optional arg when we support that, i.e. pick(options, prompt="foo")
-		return pickString(i, function, "", filters, options)
+		return pickString(i, function, prompt, filters, options)
 	case []interface{}:
 		array, ok := AsStringArray(options)
 		if !ok {
 			i.error(function, "pick() does not allow non-string arrays as options")
 		}
-		return pickString(i, function, "", filters, array)
+		return pickString(i, function, prompt, filters, array)
 	default:
 		i.error(function, "pick() takes a string array as the first argument")
 		panic(UNREACHABLE)
 	}
 }
 
+// filterArg takes the filters to narrow pick options down with, given as a single value or an array of strings.
+func filterArg(i *MainInterpreter, function Token, filter interface{}) []string {
+	switch coerced := filter.(type) {
+	case string, int64, float64, bool:
+		return []string{ToPrintable(coerced)}
+	case []string:
+		return coerced
+	case []interface{}:
+		strings, ok := AsStringArray(coerced)
+		if !ok {
+			i.error(function, function.GetLexeme()+"() does not allow non-string arrays as filters")
+		}
+		return strings
+	default:
+		i.error(function, function.GetLexeme()+"() does not allow non-string arrays as filters")
+		panic(UNREACHABLE)
+	}
+}
+
 func pickString(i *MainInterpreter, function Token, prompt string, filters []string, options []string) string {
-	var filteredOptions []huh.Option[string]
+	var filteredOptions []string
 	for _, option := range options {
-		failedAFilter := false
-		for _, filter := range filters {
-			if !fuzzy.MatchFold(filter, option) {
-				failedAFilter = true
-				break
-			}
-		}
-		if !failedAFilter {
-			filteredOptions = append(filteredOptions, huh.NewOption(option, option))
+		if matchesFilters(option, filters) {
+			filteredOptions = append(filteredOptions, option)
 		}
 	}
 
@@ -72,21 +62,26 @@ func pickString(i *MainInterpreter, function Token, prompt string, filters []str
 	}
 
 	if len(filteredOptions) == 1 {
-		return filteredOptions[0].Value
+		return filteredOptions[0]
 	}
 
-	var result string
-	// todo this probably needs to be mocked out for testing, i don't see a built-in way with huh to
-	//  e.g. provide input as part of a unit test (particularly when using stdin for the RSL script)
-	err := huh.NewSelect[string]().
-		Title(prompt).
-		Options(filteredOptions...).
-		Value(&result).
-		Run()
+	return filteredOptions[pickIndex(i, function, prompt, pickOptions(filteredOptions))]
+}
+
+func matchesFilters(option string, filters []string) bool {
+	for _, filter := range filters {
+		if !fuzzy.MatchFold(filter, option) {
+			return false
+		}
+	}
+	return true
+}
 
+// pickIndex has the picker choose between the options, returning the index of the chosen one.
+func pickIndex(i *MainInterpreter, function Token, prompt string, options []PickOption) int {
+	index, err := RPicker.Pick(prompt, options)
 	if err != nil {
-		i.error(function, fmt.Sprintf("Error running pick: %v", err))
+		i.error(function, fmt.Sprintf("Error running %s: %v", function.GetLexeme(), err))
 	}
-
-	return result
+	return index
 }
@@ -2,6 +2,8 @@ package core
 
 import (
 	"fmt"
+	"github.com/samber/lo"
+	"strings"
 )
 
 func runPickFromResource(
@@ -10,33 +12,12 @@ func runPickFromResource(
 	args []interface{},
 	numExpectedReturnValues int,
 ) interface{} {
-	numArgs := len(args)
-	if numArgs < 1 {
-		i.error(function, fmt.Sprintf("%s() takes at least one argument", PICK_FROM_RESOURCE))
+	stringFilter := ""
+	if len(args) > 1 {
+		stringFilter = ToPrintable(args[1])
 	}
 
-	if numArgs > 2 {
-		i.error(function, fmt.Sprintf("%s() takes at most two arguments, got %v", PICK_FROM_RESOURCE, numArgs))
-	}
-
-	var stringFilter string
-	switch numArgs {
-	case 1:
-		stringFilter = ""
-	case 2:
-		filter := args[1]
-		switch filter.(type) {
-		case string, int64, float64, bool:
-			stringFilter = ToPrintable(filter)
-		default:
-			i.error(function, fmt.Sprintf("%s() does not allow arrays as filters", PICK_FROM_RESOURCE))
-		}
-	}
-
-	jsonResourcePath, ok := args[0].(string)
-	if !ok {
-		i.error(function, fmt.Sprintf("%s() takes a string as the first argument", PICK_FROM_RESOURCE))
-	}
+	jsonResourcePath := args[0].(string)
 	resource := LoadPickResource(i, function, jsonResourcePath, numExpectedReturnValues)
 	return pickFromResource(i, function, stringFilter, resource)
 }
@@ -44,11 +25,8 @@ func runPickFromResource(
 func pickFromResource(i *MainInterpreter, function Token, filter string, resource PickResource) interface{} {
 	var matchedOptions []PickResourceOpt
 	for _, opt := range resource.Opts {
-		for _, key := range opt.Keys {
-			if key == filter {
-				matchedOptions = append(matchedOptions, opt)
-				break
-			}
+		if lo.Contains(opt.Keys, filter) {
+			matchedOptions = append(matchedOptions, opt)
 		}
 	}
 
@@ -57,12 +35,17 @@ func pickFromResource(i *MainInterpreter, function Token, filter string, resourc
 		i.error(function, fmt.Sprintf("Filtered %d options to 0 with filter: %q", len(resource.Opts), filter))
 	}
 
+	chosen := matchedOptions[0]
 	if len(matchedOptions) > 1 {
-		// todo here we should launch into a huh.Select
-		i.error(function, fmt.Sprintf("Filtered %d options to more than 1 with filter: %q", len(resource.Opts), filter))
+		// several options may share the filtered key, so they're picked by their other keys
+		options := lo.Map(matchedOptions, func(opt PickResourceOpt, _ int) PickOption {
+			label := strings.Join(lo.Map(opt.Values, func(v interface{}, _ int) string { return ToPrintable(v) }), ", ")
+			return PickOption{Label: label, Keys: opt.Keys}
+		})
+		chosen = matchedOptions[pickIndex(i, function, "", options)]
 	}
 
-	returnValues := matchedOptions[0].Values
+	returnValues := chosen.Values
 	if len(returnValues) == 1 {
 		return returnValues[0]
 	}
@@ -2,50 +2,30 @@ package core
 
 import (
 	"fmt"
-	"github.com/charmbracelet/huh"
-	"github.com/lithammer/fuzzysearch/fuzzy"
-	"github.com/samber/lo"
 )
 
 func runPickKv(i *MainInterpreter, function Token, args []interface{}) interface{} {
-	numArgs := len(args)
-	if numArgs < 2 {
-		i.error(function, PICK_KV+"() takes at least two arguments")
-	}
-
-	if numArgs > 3 {
-		i.error(function, fmt.Sprintf("%s() takes at most three arguments, got %v", PICK_KV, numArgs))
-	}
-
 	filters := make([]string, 0)
-	switch numArgs {
-	case 2:
-		// no filters, leave it empty
-	case 3:
-		filter := args[2]
-		switch filter.(type) {
-		case string, int64, float64, bool:
-			filters = append(filters, ToPrintable(filter))
-		case []string:
-			filters = filter.([]string)
-		case []interface{}:
-			strings, ok := AsStringArray(filter.([]interface{}))
-			if !ok {
-				i.error(function, PICK_KV+"() does not allow non-string arrays as filters")
-			}
-			filters = strings
-		default:
-			i.error(function, PICK_KV+"() does not allow non-string arrays as filters")
-		}
+	if len(args) > 2 {
+		filters = filterArg(i, function, args[2])
+	}
+	prompt := ""
+	if len(args) > 3 {
+		prompt = args[3].(string)
 	}
 
 	var keys []string
-	keys, ok := args[0].([]string)
-	if !ok {
-		if keys, ok = AsStringArray(args[0].([]interface{})); !ok {
+	switch coerced := args[0].(type) {
+	case []string:
+		keys = coerced
+	case []interface{}:
+		array, ok := AsStringArray(coerced)
+		if !ok {
 			i.error(function, PICK_KV+"() takes a string array as the first argument")
-			panic(UNREACHABLE)
 		}
+		keys = array
+	default:
+		i.error(function, PICK_KV+"() takes a string array as the first argument")
 	}
 
 	if len(keys) == 0 {
@@ -54,13 +34,13 @@ func runPickKv(i *MainInterpreter, function Token, args []interface{}) interface
 
 	switch values := args[1].(type) {
 	case []string:
-		return pickKv(i, function, "", filters, keys, values)
+		return pickKv(i, function, prompt, filters, keys, values)
 	case []int64:
-		return pickKv(i, function, "", filters, keys, values)
+		return pickKv(i, function, prompt, filters, keys, values)
 	case []float64:
-		return pickKv(i, function, "", filters, keys, values)
+		return pickKv(i, function, prompt, filters, keys, values)
 	case []interface{}:
-		return pickKv(i, function, "", filters, keys, values)
+		return pickKv(i, function, prompt, filters, keys, values)
 	default:
 		i.error(function, PICK_KV+"() takes an array as the second argument")
 		panic(UNREACHABLE)
@@ -73,41 +53,22 @@ func pickKv[T comparable](i *MainInterpreter, function Token, prompt string, fil
 			PICK_KV, len(keys), len(values)))
 	}
 
-	filteredKeyValues := make(map[string]T)
+	var filteredKeys []string
+	var filteredValues []T
 	for index, key := range keys {
-		failedAFilter := false
-		for _, filter := range filters {
-			if !fuzzy.MatchFold(filter, key) {
-				failedAFilter = true
-				break
-			}
-		}
-		if !failedAFilter {
-			filteredKeyValues[key] = values[index]
+		if matchesFilters(key, filters) {
+			filteredKeys = append(filteredKeys, key)
+			filteredValues = append(filteredValues, values[index])
 		}
 	}
 
-	if len(filteredKeyValues) == 0 {
+	if len(filteredKeys) == 0 {
 		i.error(function, fmt.Sprintf("Filtered %d keys to 0 with filters: %q", len(keys), filters))
 	}
 
-	if len(filteredKeyValues) == 1 {
-		for _, value := range filteredKeyValues {
-			return value
-		}
-	}
-
-	var result T
-	options := lo.MapToSlice(filteredKeyValues, func(k string, v T) huh.Option[T] { return huh.NewOption(k, v) })
-	err := huh.NewSelect[T]().
-		Title(prompt).
-		Options(options...).
-		Value(&result).
-		Run()
-
-	if err != nil {
-		i.error(function, fmt.Sprintf("Error running %s: %v", PICK_KV, err))
+	if len(filteredKeys) == 1 {
+		return filteredValues[0]
 	}
 
-	return result
+	return filteredValues[pickIndex(i, function, prompt, pickOptions(filteredKeys))]
 }
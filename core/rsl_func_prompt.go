@@ -0,0 +1,176 @@
+package core
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/huh"
+	"github.com/samber/lo"
+	"io"
+	"os"
+	"strings"
+)
+
+// reads scripted answers from stdin when it isn't a real file, one line per prompt. Kept across prompts, as it may
+// buffer beyond the current line.
+var scriptedAnswers *bufio.Reader
+
+func runInput(i *MainInterpreter, function Token, args []interface{}) string {
+	prompt := args[0].(string)
+	def := ""
+	if len(args) == 2 {
+		def = ToPrintable(args[1])
+	}
+
+	var result string
+	if isScripted(i, function) {
+		result = readScriptedAnswer(i, function)
+	} else {
+		err := huh.NewInput().
+			Title(prompt).
+			Placeholder(def).
+			Value(&result).
+			Run()
+		if err != nil {
+			i.error(function, fmt.Sprintf("Error running input: %v", err))
+		}
+	}
+
+	if result == "" {
+		return def
+	}
+	return result
+}
+
+func runConfirm(i *MainInterpreter, function Token, args []interface{}) bool {
+	prompt := args[0].(string)
+
+	if isScripted(i, function) {
+		answer := readScriptedAnswer(i, function)
+		switch strings.ToLower(answer) {
+		case "y", "yes", "true":
+			return true
+		case "", "n", "no", "false":
+			return false
+		default:
+			i.error(function, fmt.Sprintf("confirm() expected yes or no, got %q", answer))
+		}
+	}
+
+	var result bool
+	err := huh.NewConfirm().
+		Title(prompt).
+		Value(&result).
+		Run()
+	if err != nil {
+		i.error(function, fmt.Sprintf("Error running confirm: %v", err))
+	}
+	return result
+}
+
+// runPassword reads a value without echoing it. Like secret args, the value is a secret.
+func runPassword(i *MainInterpreter, function Token, args []interface{}) RslSecret {
+	prompt := args[0].(string)
+
+	var result string
+	if isScripted(i, function) {
+		result = readScriptedAnswer(i, function)
+	} else {
+		err := huh.NewInput().
+			Title(prompt).
+			EchoMode(huh.EchoModePassword).
+			Value(&result).
+			Run()
+		if err != nil {
+			i.error(function, fmt.Sprintf("Error running password: %v", err))
+		}
+	}
+
+	return NewRslSecret(result)
+}
+
+// runMultipick lets the user choose any number of the options, returning them in their original order.
+// Scripted answers list the chosen options separated by commas.
+func runMultipick(i *MainInterpreter, function Token, args []interface{}) []string {
+	var options []string
+	switch coerced := args[0].(type) {
+	case []string:
+		options = coerced
+	case []interface{}:
+		array, ok := AsStringArray(coerced)
+		if !ok {
+			i.error(function, "multipick() does not allow non-string arrays as options")
+		}
+		options = array
+	default:
+		i.error(function, "multipick() takes a string array as the first argument")
+	}
+	prompt := ""
+	if len(args) == 2 {
+		prompt = args[1].(string)
+	}
+
+	if len(options) == 0 {
+		i.error(function, "multipick() needs at least one option")
+	}
+
+	if isScripted(i, function) {
+		answer := readScriptedAnswer(i, function)
+		chosen := make(map[string]bool)
+		for _, choice := range strings.Split(answer, ",") {
+			choice = strings.TrimSpace(choice)
+			if choice == "" {
+				continue
+			}
+			if !lo.Contains(options, choice) {
+				i.errorWithHint(function, fmt.Sprintf("multipick() got %q, which is not one of the options", choice),
+					didYouMean(choice, options))
+			}
+			chosen[choice] = true
+		}
+		result := make([]string, 0)
+		for _, option := range options {
+			if chosen[option] {
+				result = append(result, option)
+			}
+		}
+		return result
+	}
+
+	result := make([]string, 0)
+	err := huh.NewMultiSelect[string]().
+		Title(prompt).
+		Options(huh.NewOptions(options...)...).
+		Value(&result).
+		Run()
+	if err != nil {
+		i.error(function, fmt.Sprintf("Error running multipick: %v", err))
+	}
+	return result
+}
+
+// isScripted is true if answers should be read from stdin line by line, rather than prompted for. This is the case
+// when stdin has been replaced e.g. in tests. Errors if stdin is a file but not a terminal, as there's no one to ask.
+func isScripted(i *MainInterpreter, function Token) bool {
+	if _, ok := RIo.StdIn.(*os.File); !ok {
+		return true
+	}
+	if !isInteractive() {
+		i.error(function, fmt.Sprintf("%s() needs an interactive terminal to prompt, but stdin is not a TTY",
+			function.GetLexeme()))
+	}
+	return false
+}
+
+func readScriptedAnswer(i *MainInterpreter, function Token) string {
+	if scriptedAnswers == nil {
+		scriptedAnswers = bufio.NewReader(RIo.StdIn)
+	}
+	line, err := scriptedAnswers.ReadString('\n')
+	if errors.Is(err, io.EOF) && line == "" {
+		i.error(function, fmt.Sprintf("%s() found no more answers in stdin", function.GetLexeme()))
+	} else if err != nil && !errors.Is(err, io.EOF) {
+		i.error(function, fmt.Sprintf("%s() could not read stdin: %v", function.GetLexeme(), err))
+	}
+	return strings.TrimRight(line, "\r\n")
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"fmt"
+	"math"
+)
+
+// MAX_RANGE_ARRAY_LEN is the most values range() materializes into an array, beyond which it errors rather than
+// running out of memory.
+const MAX_RANGE_ARRAY_LEN = 10_000_000
+
+// intRange is the lazy form of range(), used when it's directly iterated over by a for loop or list comprehension,
+// indexed, or given to len(), so large ranges don't need to be allocated. Anywhere else, range() materializes into an
+// int array.
+type intRange struct {
+	start int64
+	end   int64
+	step  int64
+}
+
+func newIntRange(i *MainInterpreter, function Token, args []interface{}) intRange {
+	start, end, step := int64(0), int64(0), int64(1)
+	if len(args) == 1 || args[1] == nil {
+		// only the end was given e.g. range(5)
+		end = args[0].(int64)
+	} else {
+		start, end = args[0].(int64), args[1].(int64)
+	}
+	if len(args) == 3 {
+		step = args[2].(int64)
+	}
+
+	if step == 0 {
+		i.error(function, RANGE+"() step cannot be zero")
+	}
+	return intRange{start: start, end: end, step: step}
+}
+
+// len is unsigned, as a range may hold more values than an int64 can count e.g. range(-2**63, 2**63-1). Its
+// differences are taken in uint64 too, where they can't overflow.
+func (r intRange) len() uint64 {
+	if r.step > 0 && r.start < r.end {
+		return (uint64(r.end)-uint64(r.start)-1)/uint64(r.step) + 1
+	}
+	if r.step < 0 && r.start > r.end {
+		return (uint64(r.start)-uint64(r.end)-1)/(-uint64(r.step)) + 1
+	}
+	return 0
+}
+
+// at wraps on overflow, but only in between, as every value in the range fits in an int64.
+func (r intRange) at(idx int64) int64 {
+	return r.start + idx*r.step
+}
+
+func (r intRange) materialize(i *MainInterpreter, function Token) []int64 {
+	if r.len() > MAX_RANGE_ARRAY_LEN {
+		i.errorWithHint(function, fmt.Sprintf("%s() of %d values is too large to make into an array (max %d)",
+			RANGE, r.len(), MAX_RANGE_ARRAY_LEN), "iterate over it directly with a for loop, which doesn't make an array")
+	}
+	values := make([]int64, r.len())
+	for idx := range values {
+		values[idx] = r.at(int64(idx))
+	}
+	return values
+}
+
+func runRange(i *MainInterpreter, function Token, args []interface{}) []int64 {
+	return newIntRange(i, function, args).materialize(i, function)
+}
+
+// evalRange evaluates the expression into a lazy range, if it's a direct range() call.
+func (i *MainInterpreter) evalRange(expr Expr) (intRange, bool) {
+	if call, ok := expr.(FunctionCall); ok && call.Function.GetLexeme() == RANGE {
+		return newIntRange(i, call.Function, i.evalArgs(call)), true
+	}
+	return intRange{}, false
+}
+
+// evalIterable evaluates the range of a for loop or list comprehension, keeping direct range() calls lazy.
+func (i *MainInterpreter) evalIterable(expr Expr) interface{} {
+	if r, ok := i.evalRange(expr); ok {
+		return r
+	}
+	return expr.Accept(i)
+}
+
+// evalRangeLen is len() of a direct range() call, without making it into an array.
+func (i *MainInterpreter) evalRangeLen(call FunctionCall) (int64, bool) {
+	if call.Function.GetLexeme() != "len" || len(call.Args) != 1 || len(call.NamedArgs) != 0 {
+		return 0, false
+	}
+	r, ok := i.evalRange(call.Args[0])
+	if !ok {
+		return 0, false
+	}
+	if r.len() > math.MaxInt64 {
+		i.error(call.Function, fmt.Sprintf("len() of a %s() with %d values is too large for an int", RANGE, r.len()))
+	}
+	return int64(r.len()), true
+}
@@ -9,10 +9,7 @@ import (
 // Replace allows capture group replacing, for example
 // replace("Name: abc", "a(b)c", "$1o$1") will return "Name: bobby"
 func Replace(i *MainInterpreter, function Token, oldString string, regexForOld string, regexForNew string) string {
-	re, err := regexp.Compile(regexForOld)
-	if err != nil {
-		i.error(function, fmt.Sprintf("Error compiling regex pattern: %s", err))
-	}
+	re := compileRegex(i, function, regexForOld)
 
 	replacementFunc := func(match string) string {
 		submatches := re.FindStringSubmatch(match)
@@ -34,3 +31,43 @@ func Replace(i *MainInterpreter, function Token, oldString string, regexForOld s
 
 	return newString
 }
+
+func compileRegex(i *MainInterpreter, function Token, pattern string) *regexp.Regexp {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		i.error(function, fmt.Sprintf("Error compiling regex pattern: %s", err))
+	}
+	return re
+}
+
+// runRegexMatch returns the first match followed by its capture groups, or an empty array if the pattern doesn't
+// match, so it may be used as a condition.
+func runRegexMatch(i *MainInterpreter, function Token, args []interface{}) []string {
+	re := compileRegex(i, function, ToPrintable(args[1]))
+	match := re.FindStringSubmatch(ToPrintable(args[0]))
+	if match == nil {
+		return []string{}
+	}
+	return match
+}
+
+// runRegexFindAll returns every match. If the pattern has capture groups, each match is instead an array of its
+// groups e.g. regex_find_all("a=1 b=2", "([a-z])=([0-9])") returns [["a", "1"], ["b", "2"]].
+func runRegexFindAll(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	re := compileRegex(i, function, ToPrintable(args[1]))
+	value := ToPrintable(args[0])
+
+	if re.NumSubexp() == 0 {
+		matches := re.FindAllString(value, -1)
+		if matches == nil {
+			return []string{}
+		}
+		return matches
+	}
+
+	result := make([]interface{}, 0)
+	for _, match := range re.FindAllStringSubmatch(value, -1) {
+		result = append(result, match[1:])
+	}
+	return result
+}
@@ -0,0 +1,415 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// NO_MAX_ARGS marks a function as accepting any number of arguments beyond its minimum e.g. print
+	NO_MAX_ARGS = -1
+)
+
+// FuncSignature describes how an RSL function may be called, so calls can be checked before the script runs.
+type FuncSignature struct {
+	Name   string
+	Params []FuncParam
+	// nil if the function returns nothing. A nil element means the type of that return value varies.
+	ReturnTypes []*RslTypeEnum
+	// true if the function returns however many values the caller expects e.g. pick_from_resource
+	VariableReturns bool
+}
+
+// FuncParam is a parameter of an RSL function. Args may be given for it by position, or by name e.g. pick(opts, prompt="Env?").
+type FuncParam struct {
+	Name string
+	// the types of value accepted, or any if empty. RslArrayT accepts any array.
+	Types []RslTypeEnum
+	// optional params may be omitted. If a later param is given, omitted ones take their default.
+	Optional bool
+	Default  interface{}
+	// takes all remaining positional args e.g. print's values
+	Variadic bool
+}
+
+func (s FuncSignature) IsVoid() bool {
+	return s.ReturnTypes == nil && !s.VariableReturns
+}
+
+func (s FuncSignature) MinArgs() int {
+	count := 0
+	for _, param := range s.Params {
+		if !param.Optional && !param.Variadic {
+			count++
+		}
+	}
+	return count
+}
+
+func (s FuncSignature) MaxArgs() int {
+	if s.isVariadic() {
+		return NO_MAX_ARGS
+	}
+	return len(s.Params)
+}
+
+func (s FuncSignature) isVariadic() bool {
+	return len(s.Params) > 0 && s.Params[len(s.Params)-1].Variadic
+}
+
+// Usage shows how to call the function e.g. pick(options, filter?, prompt?)
+func (s FuncSignature) Usage() string {
+	var params []string
+	for _, param := range s.Params {
+		switch {
+		case param.Variadic:
+			params = append(params, param.Name+"...")
+		case param.Optional:
+			params = append(params, param.Name+"?")
+		default:
+			params = append(params, param.Name)
+		}
+	}
+	return fmt.Sprintf("%s(%s)", s.Name, strings.Join(params, ", "))
+}
+
+var (
+	timeTypes   = []RslTypeEnum{RslDateT, RslDateTimeT, RslStringT}
+	filterTypes = []RslTypeEnum{RslStringT, RslIntT, RslFloatT, RslBoolT, RslArrayT}
+	numberTypes = []RslTypeEnum{RslIntT, RslFloatT}
+	// a function name, or an index into each value, to sort or group values by
+	keyTypes = []RslTypeEnum{RslStringT, RslIntT}
+)
+
+var FunctionSignatures = makeSignatures(
+	FuncSignature{Name: PRINT, Params: params(variadic("values"))},
+	FuncSignature{Name: PPRINT, Params: params(optional("value", nil))},
+	FuncSignature{Name: DEBUG, Params: params(variadic("values"))},
+	FuncSignature{Name: EXIT, Params: params(optional("code", int64(0), RslIntT))},
+	FuncSignature{Name: "len", Params: params(param("value", RslStringT, RslArrayT)), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "today_date", ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "today_year", ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "today_month", ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "today_day", ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "today_hour", ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "today_minute", ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "today_second", ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "epoch_seconds", Params: params(optional("time", nil, timeTypes...)), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "epoch_millis", Params: params(optional("time", nil, timeTypes...)), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "epoch_nanos", Params: params(optional("time", nil, timeTypes...)), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "now", ReturnTypes: returns(RslDateTimeT)},
+	FuncSignature{Name: "today", ReturnTypes: returns(RslDateT)},
+	FuncSignature{Name: "parse_time", Params: params(param("value", RslStringT, RslIntT, RslFloatT), optional("layout", nil, RslStringT)), ReturnTypes: returns(RslDateTimeT)},
+	FuncSignature{Name: "parse_duration", Params: params(param("value", RslStringT)), ReturnTypes: returns(RslDurationT)},
+	FuncSignature{Name: "format_time", Params: params(param("time", timeTypes...), param("layout", RslStringT)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "to_timezone", Params: params(param("time", timeTypes...), param("zone", RslStringT)), ReturnTypes: returns(RslDateTimeT)},
+	FuncSignature{Name: "time_ago", Params: params(param("time", timeTypes...)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "humanize_duration", Params: params(param("duration", RslDurationT)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "add_days", Params: params(param("time", timeTypes...), param("days", RslIntT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "add_months", Params: params(param("time", timeTypes...), param("months", RslIntT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "add_years", Params: params(param("time", timeTypes...), param("years", RslIntT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "days_between", Params: params(param("from", timeTypes...), param("to", timeTypes...)), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "replace", Params: params(param("value"), param("old"), param("new")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "join", Params: params(param("values", RslArrayT), param("separator"), optional("prefix", ""), optional("suffix", "")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "upper", Params: params(param("value")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "lower", Params: params(param("value")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "starts_with", Params: params(param("value"), param("prefix")), ReturnTypes: returns(RslBoolT)},
+	FuncSignature{Name: "ends_with", Params: params(param("value"), param("suffix")), ReturnTypes: returns(RslBoolT)},
+	FuncSignature{Name: "contains", Params: params(param("value"), param("substring")), ReturnTypes: returns(RslBoolT)},
+	FuncSignature{Name: "split", Params: params(param("value"), param("separator"), optional("limit", int64(-1), RslIntT)), ReturnTypes: returns(RslStringArrayT)},
+	FuncSignature{Name: "trim", Params: params(param("value"), optional("chars", nil)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "trim_left", Params: params(param("value"), optional("chars", nil)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "trim_right", Params: params(param("value"), optional("chars", nil)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "pad_left", Params: params(param("value"), param("width", RslIntT), optional("pad", " ")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "pad_right", Params: params(param("value"), param("width", RslIntT), optional("pad", " ")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "substring", Params: params(param("value"), param("start", RslIntT), optional("end", nil, RslIntT)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "index_of", Params: params(param("value", RslStringT, RslArrayT), param("target")), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "repeat", Params: params(param("value"), param("count", RslIntT)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "title", Params: params(param("value")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "truncate", Params: params(param("value"), param("length", RslIntT), optional("suffix", "…")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "regex_match", Params: params(param("value"), param("pattern", RslStringT)), ReturnTypes: returns(RslStringArrayT)},
+	FuncSignature{Name: "regex_find_all", Params: params(param("value"), param("pattern", RslStringT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "format", Params: params(param("template", RslStringT), variadic("values")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "url_encode", Params: params(param("value")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "url_decode", Params: params(param("value", RslStringT)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "sort", Params: params(param("values", RslArrayT), optional("key", nil, keyTypes...), optional("direction", "asc", RslStringT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "reverse", Params: params(param("values", RslStringT, RslArrayT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "uniq", Params: params(param("values", RslArrayT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "filter", Params: params(param("values", RslArrayT), optional("function", nil, RslStringT), variadic("args")), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "map", Params: params(param("values", RslArrayT), param("function", RslStringT), variadic("args")), ReturnTypes: returns(RslArrayT)},
+	FuncSignature{Name: "zip", Params: params(variadic("arrays", RslArrayT)), ReturnTypes: returns(RslArrayT)},
+	FuncSignature{Name: "flatten", Params: params(param("values", RslArrayT), optional("depth", int64(1), RslIntT)), ReturnTypes: returns(RslArrayT)},
+	FuncSignature{Name: "chunk", Params: params(param("values", RslArrayT), param("size", RslIntT)), ReturnTypes: returns(RslArrayT)},
+	FuncSignature{Name: "sum", Params: params(param("values", RslArrayT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "avg", Params: params(param("values", RslArrayT)), ReturnTypes: returns(RslFloatT)},
+	FuncSignature{Name: "min", Params: params(param("values", RslArrayT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "max", Params: params(param("values", RslArrayT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "count_by", Params: params(param("values", RslArrayT), optional("key", nil, keyTypes...)), ReturnTypes: returns(RslArrayT)},
+	FuncSignature{Name: "group_by", Params: params(param("values", RslArrayT), optional("key", nil, keyTypes...)), ReturnTypes: returns(RslArrayT)},
+	FuncSignature{Name: "abs", Params: params(param("value", numberTypes...)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "round", Params: params(param("value", numberTypes...), optional("precision", nil, RslIntT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "floor", Params: params(param("value", numberTypes...)), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "ceil", Params: params(param("value", numberTypes...)), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "sqrt", Params: params(param("value", numberTypes...)), ReturnTypes: returns(RslFloatT)},
+	FuncSignature{Name: "pow", Params: params(param("base", numberTypes...), param("exponent", numberTypes...)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "idiv", Params: params(param("dividend", numberTypes...), param("divisor", numberTypes...)), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "clamp", Params: params(param("value", numberTypes...), param("low", numberTypes...), param("high", numberTypes...)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "percent", Params: params(param("part", numberTypes...), param("total", numberTypes...), optional("precision", nil, RslIntT)), ReturnTypes: returns(RslFloatT)},
+	FuncSignature{Name: "int", Params: params(param("value")), ReturnTypes: returns(RslIntT)},
+	FuncSignature{Name: "float", Params: params(param("value")), ReturnTypes: returns(RslFloatT)},
+	FuncSignature{Name: "str", Params: params(param("value")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "bool", Params: params(param("value")), ReturnTypes: returns(RslBoolT)},
+	FuncSignature{Name: "type_of", Params: params(param("value")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "parse_json", Params: params(param("json", RslStringT), optional("path", "", RslStringT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "to_json", Params: params(param("value"), optional("indent", int64(0), RslIntT)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "pick", Params: params(param("options", RslArrayT), optional("filter", []string{}, filterTypes...), optional("prompt", "", RslStringT)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: PICK_KV, Params: params(param("keys", RslArrayT), param("values", RslArrayT), optional("filter", []string{}, filterTypes...), optional("prompt", "", RslStringT)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: PICK_FROM_RESOURCE, Params: params(param("path", RslStringT), optional("filter", "", RslStringT, RslIntT, RslFloatT, RslBoolT)), VariableReturns: true},
+	FuncSignature{Name: "multipick", Params: params(param("options", RslArrayT), optional("prompt", "", RslStringT)), ReturnTypes: returns(RslStringArrayT)},
+	FuncSignature{Name: "input", Params: params(param("prompt", RslStringT), optional("default", "")), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "confirm", Params: params(param("prompt", RslStringT)), ReturnTypes: returns(RslBoolT)},
+	FuncSignature{Name: "password", Params: params(param("prompt", RslStringT)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "env", Params: params(param("name", RslStringT), optional("default", nil)), ReturnTypes: []*RslTypeEnum{nil}},
+	FuncSignature{Name: "env_required", Params: params(param("name", RslStringT)), ReturnTypes: returns(RslStringT)},
+	FuncSignature{Name: "set_env", Params: params(param("name", RslStringT), param("value"))},
+	// range(end) counts from 0, so 'end' is optional, in which case 'start' is taken as the end
+	FuncSignature{Name: RANGE, Params: params(param("start", RslIntT), optional("end", nil, RslIntT), optional("step", int64(1), RslIntT)), ReturnTypes: returns(RslIntArrayT)},
+)
+
+func GetSignature(name string) (FuncSignature, bool) {
+	signature, ok := FunctionSignatures[name]
+	return signature, ok
+}
+
+func makeSignatures(signatures ...FuncSignature) map[string]FuncSignature {
+	result := make(map[string]FuncSignature)
+	for _, signature := range signatures {
+		result[signature.Name] = signature
+	}
+	return result
+}
+
+func returns(types ...RslTypeEnum) []*RslTypeEnum {
+	result := make([]*RslTypeEnum, len(types))
+	for idx := range types {
+		result[idx] = &types[idx]
+	}
+	return result
+}
+
+func params(params ...FuncParam) []FuncParam {
+	return params
+}
+
+func param(name string, types ...RslTypeEnum) FuncParam {
+	return FuncParam{Name: name, Types: types}
+}
+
+func optional(name string, def interface{}, types ...RslTypeEnum) FuncParam {
+	return FuncParam{Name: name, Types: types, Optional: true, Default: def}
+}
+
+func variadic(name string, types ...RslTypeEnum) FuncParam {
+	return FuncParam{Name: name, Types: types, Variadic: true}
+}
+
+// callError is a problem with the args given in a call, reported at the token.
+type callError struct {
+	token Token
+	msg   string
+	hint  string
+}
+
+// bindArgs matches a call's args to the function's params. argTypes has the type of each positional arg, followed by
+// each named arg, nil where unknown. Returns, for each param up to the last one given, the index of its arg, or -1 if
+// the param was omitted and takes its default, followed by the index of each variadic arg.
+func bindArgs(call FunctionCall, signature FuncSignature, argTypes []*RslTypeEnum) ([]int, []callError) {
+	name := signature.Name
+	usage := fmt.Sprintf("usage: `%s`", signature.Usage())
+	numPositional := len(call.Args)
+	numArgs := numPositional + len(call.NamedArgs)
+	countError := callError{
+		token: call.Function,
+		msg:   fmt.Sprintf("%s() takes %s, got %d", name, describeNumArgs(signature), numArgs),
+		hint:  usage,
+	}
+
+	params := signature.Params
+	if signature.isVariadic() {
+		params = params[:len(params)-1]
+	} else if numPositional > len(params) {
+		return nil, []callError{countError}
+	}
+
+	slots := make([]int, len(params))
+	for idx := range slots {
+		slots[idx] = -1
+		if idx < numPositional && !signature.isVariadic() {
+			slots[idx] = idx
+		}
+	}
+
+	var errs []callError
+	for idx, named := range call.NamedArgs {
+		paramName := named.Name.GetLexeme()
+		paramIdx := signature.paramIndex(paramName)
+		if paramIdx >= len(params) {
+			errs = append(errs, callError{token: named.Name, msg: fmt.Sprintf("%s() takes '%s' only as positional arguments", name, paramName), hint: usage})
+			continue
+		}
+		if paramIdx < 0 {
+			hint := didYouMean(paramName, signature.paramNames())
+			if hint == "" {
+				hint = usage
+			}
+			errs = append(errs, callError{token: named.Name, msg: fmt.Sprintf("%s() has no parameter '%s'", name, paramName), hint: hint})
+			continue
+		}
+		if slots[paramIdx] >= 0 {
+			errs = append(errs, callError{token: named.Name, msg: fmt.Sprintf("%s() got multiple values for '%s'", name, paramName)})
+			continue
+		}
+		slots[paramIdx] = numPositional + idx
+	}
+
+	// a variadic function's positional args fill the params not given by name, in order, and the rest are variadic
+	var variadicSlots []int
+	if signature.isVariadic() {
+		next := 0
+		for idx := range slots {
+			if slots[idx] < 0 && next < numPositional {
+				slots[idx] = next
+				next++
+			}
+		}
+		for ; next < numPositional; next++ {
+			variadicSlots = append(variadicSlots, next)
+		}
+	}
+
+	last := -1
+	for idx, param := range params {
+		if slots[idx] >= 0 {
+			last = idx
+			argToken := call.Function
+			if slots[idx] >= numPositional {
+				argToken = call.NamedArgs[slots[idx]-numPositional].Name
+			}
+			if err, ok := checkArgType(argToken, name, param, argTypes[slots[idx]]); !ok {
+				errs = append(errs, err)
+			}
+		} else if !param.Optional {
+			if len(call.NamedArgs) == 0 {
+				return nil, []callError{countError}
+			}
+			errs = append(errs, callError{
+				token: call.Function,
+				msg:   fmt.Sprintf("%s() is missing required argument '%s'", name, param.Name),
+				hint:  usage,
+			})
+		}
+	}
+
+	if len(variadicSlots) > 0 {
+		for _, slot := range variadicSlots {
+			if err, ok := checkArgType(call.Function, name, signature.Params[len(params)], argTypes[slot]); !ok {
+				errs = append(errs, err)
+			}
+		}
+		// every other param has been given, as positional args fill them first
+		return append(slots, variadicSlots...), errs
+	}
+	return slots[:last+1], errs
+}
+
+func (s FuncSignature) paramIndex(name string) int {
+	for idx, param := range s.Params {
+		if param.Name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (s FuncSignature) paramNames() []string {
+	names := make([]string, len(s.Params))
+	for idx, param := range s.Params {
+		names[idx] = param.Name
+	}
+	return names
+}
+
+func checkArgType(token Token, funcName string, param FuncParam, argType *RslTypeEnum) (callError, bool) {
+	if !isKnown(argType) || acceptsType(param.Types, *argType) {
+		return callError{}, true
+	}
+	return callError{
+		token: token,
+		msg: fmt.Sprintf("%s() takes %s for '%s', got %s",
+			funcName, describeTypes(param.Types), param.Name, argType.AsString()),
+	}, false
+}
+
+// acceptsType is true if a value of the type may be given for a param accepting the types. Mixed arrays are accepted
+// for any array, as their elements are checked by the function.
+func acceptsType(types []RslTypeEnum, t RslTypeEnum) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, accepted := range types {
+		if accepted == t || (accepted == RslArrayT && isArrayType(t)) || (t == RslArrayT && isArrayType(accepted)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isArrayType(t RslTypeEnum) bool {
+	switch t {
+	case RslArrayT, RslStringArrayT, RslIntArrayT, RslFloatArrayT, RslBoolArrayT:
+		return true
+	default:
+		return false
+	}
+}
+
+// describeTypes lists the types e.g. "a date, datetime, or string"
+func describeTypes(types []RslTypeEnum) string {
+	names := make([]string, len(types))
+	for idx, t := range types {
+		names[idx] = t.AsString()
+		if t == RslArrayT {
+			names[idx] = "array"
+		}
+	}
+
+	article := "a"
+	if strings.ContainsRune("aeiou", rune(names[0][0])) {
+		article = "an"
+	}
+	switch len(names) {
+	case 1:
+		return article + " " + names[0]
+	case 2:
+		return fmt.Sprintf("%s %s or %s", article, names[0], names[1])
+	default:
+		return fmt.Sprintf("%s %s, or %s", article, strings.Join(names[:len(names)-1], ", "), names[len(names)-1])
+	}
+}
+
+func describeNumArgs(signature FuncSignature) string {
+	plural := func(n int) string {
+		if n == 1 {
+			return "1 argument"
+		}
+		return fmt.Sprintf("%d arguments", n)
+	}
+
+	if signature.MaxArgs() == NO_MAX_ARGS {
+		return "at least " + plural(signature.MinArgs())
+	}
+	if signature.MinArgs() == signature.MaxArgs() {
+		return "exactly " + plural(signature.MinArgs())
+	}
+	return fmt.Sprintf("%d to %s", signature.MinArgs(), plural(signature.MaxArgs()))
+}
@@ -0,0 +1,192 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// String functions work on runes rather than bytes, so lengths and indices line up with characters, not their
+// UTF-8 encoding.
+
+// runSplit splits on every occurrence of the separator, or between each character if it's empty. A limit caps the
+// number of parts, the last of which holds the remainder.
+func runSplit(i *MainInterpreter, function Token, args []interface{}) []string {
+	value := ToPrintable(args[0])
+	separator := ToPrintable(args[1])
+	limit := -1
+	if len(args) == 3 && args[2].(int64) > 0 {
+		limit = int(args[2].(int64))
+	}
+
+	if separator == "" {
+		runes := []rune(value)
+		parts := make([]string, 0, len(runes))
+		for idx, r := range runes {
+			if idx == limit-1 {
+				return append(parts, string(runes[idx:]))
+			}
+			parts = append(parts, string(r))
+		}
+		return parts
+	}
+	return strings.SplitN(value, separator, limit)
+}
+
+// runTrim removes whitespace, or else any of the given characters, from both ends for trim(), or one end for
+// trim_left() and trim_right().
+func runTrim(i *MainInterpreter, function Token, args []interface{}) string {
+	value := ToPrintable(args[0])
+
+	if len(args) == 1 {
+		switch function.GetLexeme() {
+		case "trim_left":
+			return strings.TrimLeftFunc(value, unicode.IsSpace)
+		case "trim_right":
+			return strings.TrimRightFunc(value, unicode.IsSpace)
+		default:
+			return strings.TrimSpace(value)
+		}
+	}
+
+	chars := ToPrintable(args[1])
+	switch function.GetLexeme() {
+	case "trim_left":
+		return strings.TrimLeft(value, chars)
+	case "trim_right":
+		return strings.TrimRight(value, chars)
+	default:
+		return strings.Trim(value, chars)
+	}
+}
+
+// runPad pads the value to the given width in characters, repeating the pad as needed. Values already at least that
+// wide are returned as-is.
+func runPad(i *MainInterpreter, function Token, args []interface{}) string {
+	value := ToPrintable(args[0])
+	width := int(args[1].(int64))
+	pad := " "
+	if len(args) == 3 {
+		pad = ToPrintable(args[2])
+	}
+	if pad == "" {
+		i.error(function, fmt.Sprintf("%s() needs a non-empty pad", function.GetLexeme()))
+	}
+
+	missing := width - utf8.RuneCountInString(value)
+	if missing <= 0 {
+		return value
+	}
+	padRunes := []rune(strings.Repeat(pad, missing/utf8.RuneCountInString(pad)+1))
+	padding := string(padRunes[:missing])
+
+	if function.GetLexeme() == "pad_left" {
+		return padding + value
+	}
+	return value + padding
+}
+
+// runSubstring returns the characters from start up to, but excluding, end. Negative indices count back from the end
+// of the string, and indices beyond either end are clamped to it.
+func runSubstring(i *MainInterpreter, function Token, args []interface{}) string {
+	runes := []rune(ToPrintable(args[0]))
+	start := clampIndex(args[1].(int64), len(runes))
+	end := len(runes)
+	if len(args) == 3 && args[2] != nil {
+		end = clampIndex(args[2].(int64), len(runes))
+	}
+
+	if start >= end {
+		return ""
+	}
+	return string(runes[start:end])
+}
+
+func clampIndex(idx int64, length int) int {
+	if idx < 0 {
+		idx += int64(length)
+	}
+	return int(max(0, min(idx, int64(length))))
+}
+
+// runIndexOf returns the character index of the first occurrence of the target in a string, or the index of the
+// first value equal to it in an array, or -1 if it doesn't occur.
+func runIndexOf(i *MainInterpreter, function Token, args []interface{}) int64 {
+	if values, ok := ToMixedArray(args[0]); ok {
+		return arrayIndexOf(values, args[1])
+	}
+
+	value := ToPrintable(args[0])
+	byteIdx := strings.Index(value, ToPrintable(args[1]))
+	if byteIdx < 0 {
+		return -1
+	}
+	return int64(utf8.RuneCountInString(value[:byteIdx]))
+}
+
+func runRepeat(i *MainInterpreter, function Token, args []interface{}) string {
+	count := args[1].(int64)
+	if count < 0 {
+		i.error(function, fmt.Sprintf("repeat() count must not be negative, got %d", count))
+	}
+	return strings.Repeat(ToPrintable(args[0]), int(count))
+}
+
+// runTitle upper-cases the first letter of each word, leaving the rest as they are, so acronyms survive.
+func runTitle(i *MainInterpreter, function Token, args []interface{}) string {
+	var sb strings.Builder
+	prev := ' '
+	for _, r := range ToPrintable(args[0]) {
+		if isWordRune(prev) {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune(unicode.ToUpper(r))
+		}
+		prev = r
+	}
+	return sb.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '\''
+}
+
+// runTruncate cuts the value down to at most the given number of characters, ending with the suffix if anything
+// was cut.
+func runTruncate(i *MainInterpreter, function Token, args []interface{}) string {
+	runes := []rune(ToPrintable(args[0]))
+	length := args[1].(int64)
+	suffix := "…"
+	if len(args) == 3 {
+		suffix = ToPrintable(args[2])
+	}
+
+	if length < 0 {
+		i.error(function, fmt.Sprintf("truncate() length must not be negative, got %d", length))
+	}
+	if int64(len(runes)) <= length {
+		return string(runes)
+	}
+
+	suffixRunes := []rune(suffix)
+	if int64(len(suffixRunes)) >= length {
+		return string(suffixRunes[:length])
+	}
+	return string(runes[:length-int64(len(suffixRunes))]) + suffix
+}
+
+// runUrlEncode escapes the value for use in a URL query e.g. "a b&c" becomes "a+b%26c".
+func runUrlEncode(i *MainInterpreter, function Token, args []interface{}) string {
+	return url.QueryEscape(ToPrintable(args[0]))
+}
+
+func runUrlDecode(i *MainInterpreter, function Token, args []interface{}) string {
+	value := ToPrintable(args[0])
+	decoded, err := url.QueryUnescape(value)
+	if err != nil {
+		i.error(function, fmt.Sprintf("Could not URL-decode %q: %v", value, err))
+	}
+	return decoded
+}
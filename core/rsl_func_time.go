@@ -0,0 +1,343 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	_ "time/tzdata" // so time zones convert the same everywhere, even without the system's database
+)
+
+// runParseTime parses a timestamp into a datetime. Without a layout, ISO-8601 and relative times e.g. -2h are
+// accepted. Layouts may be strftime-style e.g. "%d/%m/%Y", or Go-style e.g. "02/01/2006". Numbers are taken as
+// epoch seconds.
+func runParseTime(i *MainInterpreter, function Token, args []interface{}) RslDateTime {
+	switch value := args[0].(type) {
+	case int64:
+		return RslDateTime{Time: time.Unix(value, 0).In(RClock.Now().Location())}
+	case float64:
+		secs, frac := math.Modf(value)
+		return RslDateTime{Time: time.Unix(int64(secs), int64(frac*1e9)).In(RClock.Now().Location())}
+	case string:
+		if len(args) == 1 {
+			parsed, err := ParseDateTime(value, RClock.Now())
+			if err != nil {
+				i.error(function, fmt.Sprintf("Could not parse time: %v", err))
+			}
+			return parsed
+		}
+
+		layout := args[1].(string)
+		var parsed time.Time
+		var err error
+		if strings.Contains(layout, "%") {
+			parsed, err = strptime(i, function, layout, value, RClock.Now().Location())
+		} else {
+			parsed, err = time.ParseInLocation(layout, value, RClock.Now().Location())
+		}
+		if err != nil {
+			i.error(function, fmt.Sprintf("Could not parse time %q with layout %q", value, layout))
+		}
+		return RslDateTime{Time: parsed}
+	default:
+		i.error(function, "parse_time() takes a string or epoch seconds as the first argument")
+		panic(UNREACHABLE)
+	}
+}
+
+func runParseDuration(i *MainInterpreter, function Token, args []interface{}) RslDuration {
+	duration, err := ParseDuration(args[0].(string))
+	if err != nil {
+		i.error(function, fmt.Sprintf("Could not parse duration: %v", err))
+	}
+	return duration
+}
+
+// runFormatTime formats a date or datetime with a strftime-style layout e.g. "%Y-%m-%d", or a Go-style one
+// e.g. "2006-01-02".
+func runFormatTime(i *MainInterpreter, function Token, args []interface{}) string {
+	t := timeArg(i, function, args[0])
+	layout := args[1].(string)
+	if strings.Contains(layout, "%") {
+		formatted, err := strftime(t, layout)
+		if err != nil {
+			i.error(function, err.Error())
+		}
+		return formatted
+	}
+	return t.Format(layout)
+}
+
+func runToTimezone(i *MainInterpreter, function Token, args []interface{}) RslDateTime {
+	t := timeArg(i, function, args[0])
+	name := args[1].(string)
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		i.error(function, fmt.Sprintf("Unknown time zone: %q", name))
+	}
+	return RslDateTime{Time: t.In(location)}
+}
+
+// runTimeAgo describes how long ago a time was, or how long until it is, relative to now e.g. "3 days ago".
+func runTimeAgo(i *MainInterpreter, function Token, args []interface{}) string {
+	since := RClock.Now().Sub(timeArg(i, function, args[0]))
+	if since > -time.Minute && since < time.Minute {
+		return "just now"
+	}
+	if since < 0 {
+		return "in " + humanizeDuration(-since)
+	}
+	return humanizeDuration(since) + " ago"
+}
+
+func runHumanizeDuration(i *MainInterpreter, function Token, args []interface{}) string {
+	duration := args[0].(RslDuration)
+	if duration < 0 {
+		return "-" + humanizeDuration(-time.Duration(duration))
+	}
+	return humanizeDuration(time.Duration(duration))
+}
+
+// humanizeDuration describes the duration in its largest whole unit e.g. "2 hours", rounding down.
+func humanizeDuration(duration time.Duration) string {
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{365 * DAY, "year"},
+		{30 * DAY, "month"},
+		{WEEK, "week"},
+		{DAY, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+		{time.Second, "second"},
+	}
+	for _, unit := range units {
+		if count := int64(duration / unit.size); count > 0 {
+			return pluralize(count, unit.name)
+		}
+	}
+	return "0 seconds"
+}
+
+func pluralize(count int64, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// runAddCalendar adds whole days, months, or years to a date or datetime, keeping its time of day. Unlike adding
+// durations, this follows the calendar. Days past the end of the resulting month are clamped to its last day, so e.g.
+// a month after January 31st is the last day of February, rather than overflowing into March.
+func runAddCalendar(i *MainInterpreter, function Token, args []interface{}) interface{} {
+	n := args[1].(int64)
+
+	var years, months, days int
+	switch function.GetLexeme() {
+	case "add_days":
+		days = int(n)
+	case "add_months":
+		months = int(n)
+	default:
+		years = int(n)
+	}
+
+	t := addCalendar(timeArg(i, function, args[0]), years, months, days)
+	if _, isDate := args[0].(RslDate); isDate {
+		return RslDate{Time: t}
+	}
+	return RslDateTime{Time: t}
+}
+
+func addCalendar(t time.Time, years, months, days int) time.Time {
+	year, month, day := t.Date()
+	hour, minute, sec := t.Clock()
+	// day 0 of the month after is the last day of the target month
+	lastDay := time.Date(year+years, month+time.Month(months)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	return time.Date(year+years, month+time.Month(months), min(day, lastDay)+days, hour, minute, sec, t.Nanosecond(),
+		t.Location())
+}
+
+// runDaysBetween counts the calendar days from the first date to the second, negative if the second is earlier.
+func runDaysBetween(i *MainInterpreter, function Token, args []interface{}) int64 {
+	from := NewRslDate(timeArg(i, function, args[0])).Time
+	to := NewRslDate(timeArg(i, function, args[1]).In(from.Location())).Time
+	// via UTC, so days either side of a daylight saving change are still 24 hours apart
+	fromUtc := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toUtc := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(toUtc.Sub(fromUtc) / DAY)
+}
+
+// runEpoch returns the given time as epoch seconds, millis, or nanos, or now if no time is given.
+func runEpoch(i *MainInterpreter, function Token, args []interface{}) int64 {
+	t := RClock.Now()
+	if len(args) == 1 {
+		t = timeArg(i, function, args[0])
+	}
+
+	switch function.GetLexeme() {
+	case "epoch_seconds":
+		return t.Unix()
+	case "epoch_millis":
+		return t.UnixMilli()
+	default:
+		return t.UnixNano()
+	}
+}
+
+// timeArg accepts dates, datetimes, and ISO-8601 strings, as most APIs return times as strings.
+func timeArg(i *MainInterpreter, function Token, arg interface{}) time.Time {
+	switch coerced := arg.(type) {
+	case RslDate:
+		return coerced.Time
+	case RslDateTime:
+		return coerced.Time
+	case string:
+		parsed, err := ParseDateTime(coerced, RClock.Now())
+		if err != nil {
+			i.error(function, fmt.Sprintf("Could not parse time: %v", err))
+		}
+		return parsed.Time
+	default:
+		i.error(function, fmt.Sprintf("%s() takes a date, datetime, or ISO-8601 string, got %s",
+			function.GetLexeme(), NewRuntimeLiteral(arg).Type.AsString()))
+		panic(UNREACHABLE)
+	}
+}
+
+// strptime parses the value with a strftime-style layout. Like strftime, it goes directive by directive: the value's
+// part for each directive is picked out, and only those parts are parsed with Go layouts, so literal text in the
+// layout can't be mistaken for parts of one.
+func strptime(i *MainInterpreter, function Token, layout string, value string, loc *time.Location) (time.Time, error) {
+	var pattern strings.Builder
+	var directives []rune
+	pattern.WriteString("^")
+	runes := []rune(layout)
+	for idx := 0; idx < len(runes); idx++ {
+		if runes[idx] != '%' || idx+1 == len(runes) {
+			pattern.WriteString(regexp.QuoteMeta(string(runes[idx])))
+			continue
+		}
+		idx++
+		if runes[idx] == '%' {
+			pattern.WriteRune('%')
+			continue
+		}
+		directivePattern, ok := strptimePatterns[runes[idx]]
+		if !ok {
+			i.error(function, fmt.Sprintf("Unsupported directive for parsing: %%%c", runes[idx]))
+		}
+		pattern.WriteString("(" + directivePattern + ")")
+		directives = append(directives, runes[idx])
+	}
+	pattern.WriteString("$")
+	if len(directives) == 0 {
+		// would otherwise parse nothing, giving the zero time
+		return time.Time{}, fmt.Errorf("layout %q has no directives", layout)
+	}
+
+	match := regexp.MustCompile(pattern.String()).FindStringSubmatch(value)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("%q doesn't match layout %q", value, layout)
+	}
+	goLayouts := make([]string, len(directives))
+	parts := make([]string, len(directives))
+	for idx, directive := range directives {
+		goLayouts[idx], parts[idx] = strftimeLayouts[directive], match[idx+1]
+		if directive == 'f' {
+			goLayouts[idx], parts[idx] = ".999999999", "."+parts[idx]
+		}
+	}
+	// separated by a character with no meaning in Go layouts
+	return time.ParseInLocation(strings.Join(goLayouts, "|"), strings.Join(parts, "|"), loc)
+}
+
+// patterns matching the part of a value parsed by each strftime directive, as accepted by its Go layout
+var strptimePatterns = map[rune]string{
+	'Y': `[0-9]{4}`,
+	'y': `[0-9]{2}`,
+	'm': `[0-9]{2}`,
+	'd': `[0-9]{2}`,
+	'e': ` ?[0-9]{1,2}`,
+	'H': `[0-9]{1,2}`,
+	'I': `[0-9]{2}`,
+	'M': `[0-9]{2}`,
+	'S': `[0-9]{2}`,
+	'f': `[0-9]+`,
+	'p': `[AaPp][Mm]`,
+	'b': `[A-Za-z]{3}`,
+	'h': `[A-Za-z]{3}`,
+	'B': `[A-Za-z]+`,
+	'a': `[A-Za-z]{3}`,
+	'A': `[A-Za-z]+`,
+	'Z': `[A-Za-z]+(?:[+-][0-9]+)?`,
+	'z': `[+-][0-9]{4}`,
+	'F': `[0-9]{4}-[0-9]{2}-[0-9]{2}`,
+	'T': `[0-9]{1,2}:[0-9]{2}:[0-9]{2}`,
+	'R': `[0-9]{1,2}:[0-9]{2}`,
+}
+
+// Go layouts for strftime directives
+var strftimeLayouts = map[rune]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'Z': "MST",
+	'z': "-0700",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'R': "15:04",
+	'%': "%",
+}
+
+// strftime formats directive by directive, so literal text can't be mistaken for parts of a Go layout.
+func strftime(t time.Time, layout string) (string, error) {
+	var sb strings.Builder
+	runes := []rune(layout)
+	for idx := 0; idx < len(runes); idx++ {
+		if runes[idx] != '%' || idx+1 == len(runes) {
+			sb.WriteRune(runes[idx])
+			continue
+		}
+		idx++
+		switch directive := runes[idx]; directive {
+		case '%':
+			sb.WriteRune('%')
+		case 'f':
+			sb.WriteString(fmt.Sprintf("%06d", t.Nanosecond()/1000))
+		case 'j':
+			sb.WriteString(fmt.Sprintf("%03d", t.YearDay()))
+		case 's':
+			sb.WriteString(fmt.Sprintf("%d", t.Unix()))
+		case 'u':
+			weekday := int(t.Weekday())
+			if weekday == 0 {
+				weekday = 7
+			}
+			sb.WriteString(fmt.Sprintf("%d", weekday))
+		case 'w':
+			sb.WriteString(fmt.Sprintf("%d", t.Weekday()))
+		default:
+			goLayout, ok := strftimeLayouts[directive]
+			if !ok {
+				return "", fmt.Errorf("Unsupported directive: %%%c", directive)
+			}
+			sb.WriteString(t.Format(goLayout))
+		}
+	}
+	return sb.String(), nil
+}
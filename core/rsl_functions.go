@@ -2,8 +2,10 @@ package core
 
 import (
 	"fmt"
+	"github.com/samber/lo"
 	"os"
 	"strings"
+	"unicode/utf8"
 )
 
 // RunRslNonVoidFunction returns pointers to values e.g. *string
@@ -14,6 +16,7 @@ func RunRslNonVoidFunction(
 	args []interface{},
 ) interface{} {
 	functionName := function.GetLexeme()
+	args = jsonObjectArgsAsText(functionName, args)
 
 	switch functionName {
 	case "len":
@@ -40,15 +43,39 @@ func RunRslNonVoidFunction(
 	case "today_second":
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
 		return int64(RClock.Now().Second())
-	case "epoch_seconds":
+	case "epoch_seconds", "epoch_millis", "epoch_nanos":
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
-		return RClock.Now().Unix()
-	case "epoch_millis":
+		return runEpoch(i, function, args)
+	case "now":
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
-		return RClock.Now().UnixMilli()
-	case "epoch_nanos":
+		return RslDateTime{Time: RClock.Now()}
+	case "today":
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
-		return RClock.Now().UnixNano()
+		return NewRslDate(RClock.Now())
+	case "parse_time":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runParseTime(i, function, args)
+	case "parse_duration":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runParseDuration(i, function, args)
+	case "format_time":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runFormatTime(i, function, args)
+	case "to_timezone":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runToTimezone(i, function, args)
+	case "time_ago":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runTimeAgo(i, function, args)
+	case "humanize_duration":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runHumanizeDuration(i, function, args)
+	case "add_days", "add_months", "add_years":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runAddCalendar(i, function, args)
+	case "days_between":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runDaysBetween(i, function, args)
 	case "replace":
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
 		return runReplace(i, function, args)
@@ -62,29 +89,164 @@ func RunRslNonVoidFunction(
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
 		return strings.ToLower(ToPrintable(args[0]))
 	case "starts_with":
-		if len(args) != 2 {
-			i.error(function, "starts_with() takes exactly two arguments")
-		}
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
 		return strings.HasPrefix(ToPrintable(args[0]), ToPrintable(args[1]))
 	case "ends_with":
-		if len(args) != 2 {
-			i.error(function, "ends_with() takes exactly two arguments")
-		}
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
 		return strings.HasSuffix(ToPrintable(args[0]), ToPrintable(args[1]))
 	case "contains":
-		if len(args) != 2 {
-			i.error(function, "contains() takes exactly two arguments")
-		}
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
 		return strings.Contains(ToPrintable(args[0]), ToPrintable(args[1]))
+	case "split":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runSplit(i, function, args)
+	case "trim", "trim_left", "trim_right":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runTrim(i, function, args)
+	case "pad_left", "pad_right":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runPad(i, function, args)
+	case "substring":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runSubstring(i, function, args)
+	case "index_of":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runIndexOf(i, function, args)
+	case "repeat":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runRepeat(i, function, args)
+	case "title":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runTitle(i, function, args)
+	case "truncate":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runTruncate(i, function, args)
+	case "regex_match":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runRegexMatch(i, function, args)
+	case "regex_find_all":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runRegexFindAll(i, function, args)
+	case "format":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runFormat(i, function, args)
+	case "url_encode":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runUrlEncode(i, function, args)
+	case "url_decode":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runUrlDecode(i, function, args)
+	case "sort":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runSort(i, function, args)
+	case "reverse":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runReverse(i, function, args)
+	case "uniq":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runUniq(i, function, args)
+	case "filter":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runFilter(i, function, args)
+	case "map":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runMap(i, function, args)
+	case "zip":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runZip(i, function, args)
+	case "flatten":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runFlatten(i, function, args)
+	case "chunk":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runChunk(i, function, args)
+	case "sum":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runSum(i, function, args)
+	case "avg":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runAvg(i, function, args)
+	case "min", "max":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runMinMax(i, function, args)
+	case "count_by":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runCountBy(i, function, args)
+	case "group_by":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runGroupBy(i, function, args)
+	case "abs":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runAbs(i, function, args)
+	case "round":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runRound(i, function, args)
+	case "floor", "ceil":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runFloorCeil(i, function, args)
+	case "sqrt":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runSqrt(i, function, args)
+	case "pow":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runPow(i, function, args)
+	case "idiv":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runIdiv(i, function, args)
+	case "clamp":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runClamp(i, function, args)
+	case "percent":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runPercent(i, function, args)
+	case "int":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runInt(i, function, args)
+	case "float":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runFloat(i, function, args)
+	case "str":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return ToPrintable(args[0])
+	case "bool":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runBool(i, function, args)
+	case "type_of":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runTypeOf(i, function, args)
+	case "parse_json":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runParseJson(i, function, args)
+	case "to_json":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runToJson(i, function, args)
 	case "pick":
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
 		return runPick(i, function, args)
 	case PICK_KV:
 		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
 		return runPickKv(i, function, args)
+	case RANGE:
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runRange(i, function, args)
+	case "env":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runEnv(i, function, args)
+	case "env_required":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runEnvRequired(i, function, args)
+	case "input":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runInput(i, function, args)
+	case "confirm":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runConfirm(i, function, args)
+	case "password":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runPassword(i, function, args)
+	case "multipick":
+		assertExpectedNumReturnValues(i, function, functionName, numExpectedReturnValues, 1)
+		return runMultipick(i, function, args)
 	case PICK_FROM_RESOURCE:
 		return runPickFromResource(i, function, args, numExpectedReturnValues)
 	default:
@@ -95,42 +257,75 @@ func RunRslNonVoidFunction(
 
 func RunRslFunction(i *MainInterpreter, function Token, args []interface{}) {
 	functionName := function.GetLexeme()
+	args = jsonObjectArgsAsText(functionName, args)
 	switch functionName {
 	case PRINT:
 		runPrint(args)
 	case PPRINT:
-		if len(args) > 1 {
-			i.error(function, PPRINT+"() takes zero or one argument")
-		}
 		runPrettyPrint(i, function, args)
 	case DEBUG:
 		runDebug(args)
+	case "set_env":
+		runSetEnv(i, function, args)
 	case EXIT:
-		if len(args) == 0 {
-			os.Exit(0)
-		} else if len(args) == 1 {
-			arg := args[0]
-			switch coerced := arg.(type) {
-			case int64:
-				os.Exit(int(coerced))
-			default:
-				i.error(function, "exit() takes an integer argument")
-			}
-		} else {
-			i.error(function, "exit() takes zero or one argument")
+		code := int64(0)
+		if len(args) == 1 {
+			code = args[0].(int64)
 		}
+		os.Exit(int(code))
 	default:
 		RunRslNonVoidFunction(i, function, NO_NUM_RETURN_VALUES_CONSTRAINT, args)
 	}
 }
 
-func runLen(i *MainInterpreter, function Token, values []interface{}) int64 {
-	if len(values) != 1 {
-		i.error(function, "len() takes exactly one argument")
+// jsonObjectArgsAsText gives functions any JSON objects in their args as text, as that's what they are to scripts. Only
+// to_json() sees them as objects, to write them back out as such.
+func jsonObjectArgsAsText(functionName string, args []interface{}) []interface{} {
+	if functionName == "to_json" {
+		return args
+	}
+	replaced, _ := jsonObjectsAsText(args)
+	return replaced.([]interface{})
+}
+
+// callFunctionByName calls a function named in a script e.g. by map(names, "upper"), checking the args against its
+// signature as a call written out in the script would be.
+func callFunctionByName(i *MainInterpreter, caller Token, name string, args []interface{}) interface{} {
+	callerName := caller.GetLexeme()
+	signature, ok := GetSignature(name)
+	if !ok {
+		i.errorWithHint(caller, fmt.Sprintf("%s() got unknown function %q", callerName, name),
+			didYouMean(name, lo.Keys(FunctionSignatures)))
+	}
+	if signature.IsVoid() || signature.VariableReturns {
+		i.error(caller, fmt.Sprintf("%s() needs a function that returns a value, but %s() does not", callerName, name))
 	}
+
+	if len(args) < signature.MinArgs() || (signature.MaxArgs() != NO_MAX_ARGS && len(args) > signature.MaxArgs()) {
+		i.errorWithHint(caller, fmt.Sprintf("%s() calls %s(), which takes %s, got %d",
+			callerName, name, describeNumArgs(signature), len(args)), fmt.Sprintf("usage: `%s`", signature.Usage()))
+	}
+	for idx, arg := range args {
+		param := signature.Params[min(idx, len(signature.Params)-1)]
+		if err, ok := checkArgType(caller, name, param, typeOf(NewRuntimeLiteral(arg).Type)); !ok {
+			i.error(caller, err.msg)
+		}
+	}
+
+	function := BaseToken{
+		Type:          IDENTIFIER,
+		Lexeme:        name,
+		CharStart:     caller.GetCharStart(),
+		Line:          caller.GetLine(),
+		CharLineStart: caller.GetCharLineStart(),
+	}
+	return RunRslNonVoidFunction(i, function, 1, args)
+}
+
+func runLen(i *MainInterpreter, function Token, values []interface{}) int64 {
 	switch v := values[0].(type) {
 	case string:
-		return int64(len(v))
+		return int64(utf8.RuneCountInString(v))
 	case []string:
 		return int64(len(v))
 	case []int64:
@@ -146,10 +341,6 @@ func runLen(i *MainInterpreter, function Token, values []interface{}) int64 {
 }
 
 func runReplace(i *MainInterpreter, function Token, values []interface{}) interface{} {
-	if len(values) != 3 {
-		i.error(function, "replace() takes exactly three arguments")
-	}
-
 	subject := ToPrintable(values[0])
 	oldRegex := ToPrintable(values[1])
 	newRegex := ToPrintable(values[2])
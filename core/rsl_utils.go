@@ -5,6 +5,44 @@ import (
 	"strconv"
 )
 
+// IsTruthy determines how a value behaves as a condition e.g. in an if statement. null, false, zero, and empty
+// strings and arrays are falsy, everything else is truthy.
+func IsTruthy(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case RslSecret:
+		return v.value != ""
+	case RslJsonObject:
+		return true
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0
+	case []string:
+		return len(v) > 0
+	case []int64:
+		return len(v) > 0
+	case []float64:
+		return len(v) > 0
+	case []bool:
+		return len(v) > 0
+	case []interface{}:
+		return len(v) > 0
+	case RslDuration:
+		return v != 0
+	case RslDate, RslDateTime:
+		return true
+	default:
+		RP.RadErrorExit(fmt.Sprintf("unknown type: %T", val))
+		panic(UNREACHABLE)
+	}
+}
+
 func ToPrintable(val interface{}) string {
 	switch v := val.(type) {
 	case int64:
@@ -15,6 +53,8 @@ func ToPrintable(val interface{}) string {
 		return v
 	case bool:
 		return strconv.FormatBool(v)
+	case nil:
+		return "null"
 	case []int64:
 		out := "["
 		for i, elem := range v {
@@ -60,6 +100,8 @@ func ToPrintable(val interface{}) string {
 			out += ToPrintable(elem)
 		}
 		return out + "]"
+	case RslDate, RslDateTime, RslDuration, RslSecret, RslJsonObject:
+		return v.(fmt.Stringer).String()
 	default:
 		RP.RadErrorExit(fmt.Sprintf("unknown type: %T", val))
 		panic(UNREACHABLE)
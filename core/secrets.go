@@ -0,0 +1,160 @@
+package core
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"golang.org/x/term"
+	"os"
+	"strings"
+)
+
+const (
+	REDACTED = "****"
+)
+
+// functions which print or display their args, so are given secrets redacted, rather than revealed
+var DISPLAYING_FUNCTIONS = []string{PRINT, PPRINT, DEBUG, "input", "confirm", "password", "pick", "multipick"}
+
+// RslSecret is a string holding a secret, or made from one e.g. by upper(token) or "Bearer {token}". It's redacted
+// wherever rad prints it, and its value is only revealed to make requests.
+type RslSecret struct {
+	value string
+	// printed in its place, where only the parts made from secrets are redacted e.g. "Bearer ****"
+	redacted string
+}
+
+func NewRslSecret(value string) RslSecret {
+	return RslSecret{value: value, redacted: REDACTED}
+}
+
+func (s RslSecret) String() string {
+	return s.redacted
+}
+
+// revealForRequest returns the value to request, and how to print it, for a string which may be secret.
+func revealForRequest(value interface{}) (string, string) {
+	if secret, ok := value.(RslSecret); ok {
+		return secret.value, secret.redacted
+	}
+	return value.(string), value.(string)
+}
+
+// concatSecrets joins strings, which may be secrets, into a secret.
+func concatSecrets(parts ...interface{}) RslSecret {
+	var value, redacted strings.Builder
+	for _, part := range parts {
+		if secret, ok := part.(RslSecret); ok {
+			value.WriteString(secret.value)
+			redacted.WriteString(secret.redacted)
+		} else {
+			value.WriteString(ToPrintable(part))
+			redacted.WriteString(ToPrintable(part))
+		}
+	}
+	return RslSecret{value: value.String(), redacted: redacted.String()}
+}
+
+func isSecret(value interface{}) bool {
+	_, ok := value.(RslSecret)
+	return ok
+}
+
+// hasSecrets is true if the value is, or is an array containing, a secret.
+func hasSecrets(value interface{}) bool {
+	_, found := mapSecrets(value, func(secret RslSecret) interface{} { return secret })
+	return found
+}
+
+// revealSecrets replaces any secrets in the values, including in arrays, with their values. Returns whether there
+// were any.
+func revealSecrets(values []interface{}) ([]interface{}, bool) {
+	revealed, found := mapSecrets(values, func(secret RslSecret) interface{} { return secret.value })
+	return revealed.([]interface{}), found
+}
+
+// redactSecrets replaces any secrets in the values, including in arrays, with how they're printed.
+func redactSecrets(values []interface{}) []interface{} {
+	redacted, _ := mapSecrets(values, func(secret RslSecret) interface{} { return secret.redacted })
+	return redacted.([]interface{})
+}
+
+func mapSecrets(value interface{}, mapper func(RslSecret) interface{}) (interface{}, bool) {
+	switch coerced := value.(type) {
+	case RslSecret:
+		return mapper(coerced), true
+	case []interface{}:
+		mapped := make([]interface{}, len(coerced))
+		found := false
+		for idx, elem := range coerced {
+			var elemFound bool
+			mapped[idx], elemFound = mapSecrets(elem, mapper)
+			found = found || elemFound
+		}
+		if !found {
+			return value, false
+		}
+		return mapped, true
+	default:
+		return value, false
+	}
+}
+
+// concealSecrets makes the strings in a value computed from secrets secret too, so they stay redacted. Other values
+// e.g. lengths are left as they are.
+func concealSecrets(value interface{}) interface{} {
+	switch coerced := value.(type) {
+	case string:
+		return NewRslSecret(coerced)
+	case []string:
+		concealed := make([]interface{}, len(coerced))
+		for idx, elem := range coerced {
+			concealed[idx] = NewRslSecret(elem)
+		}
+		return concealed
+	case []interface{}:
+		concealed := make([]interface{}, len(coerced))
+		for idx, elem := range coerced {
+			concealed[idx] = concealSecrets(elem)
+		}
+		return concealed
+	default:
+		return value
+	}
+}
+
+// callWithSecrets runs a function with the args. Functions displaying their args are given secrets redacted, while
+// others are given them revealed, in which case what they return is kept secret, as it may be made from them.
+func callWithSecrets(function Token, args []interface{}, run func(args []interface{}) interface{}) interface{} {
+	if lo.Contains(DISPLAYING_FUNCTIONS, function.GetLexeme()) {
+		return run(redactSecrets(args))
+	}
+	revealed, found := revealSecrets(args)
+	if !found {
+		return run(args)
+	}
+	result := run(revealed)
+	if function.GetLexeme() == "type_of" {
+		// reveals nothing of the secret
+		return result
+	}
+	return concealSecrets(result)
+}
+
+// isInteractive is true if stdin is a terminal, so there's someone to prompt.
+func isInteractive() bool {
+	stdin, ok := RIo.StdIn.(*os.File)
+	return ok && term.IsTerminal(int(stdin.Fd()))
+}
+
+// promptForSecret reads the arg's value from the terminal, without echoing it.
+func promptForSecret(arg ScriptArg) string {
+	fd := int(RIo.StdIn.(*os.File).Fd())
+	stdErr := RIo.StdErr
+	fmt.Fprintf(stdErr, "%s: ", arg.ApiName)
+	value, err := term.ReadPassword(fd)
+	fmt.Fprintln(stdErr)
+	if err != nil {
+		RP.RadErrorExit(fmt.Sprintf("Could not read '%s': %v\n", arg.ApiName, err))
+	}
+	return string(value)
+}
@@ -0,0 +1,269 @@
+package testing
+
+import "testing"
+
+const (
+	setupConstraintsRsl = `
+args:
+	city string?
+	country string?
+	name string = "bob"
+	count int = 3
+	verbose V bool
+	at_least 1: city, country
+	name regex "^[a-z]+$"
+	count >= 1
+	count < 10
+	verbose requires name
+print(city, country, name, count)
+`
+	constraintsUsage = `Usage:
+  test [city] [country] [name] [count] [--verbose] [flags]
+
+Flags:
+      --city string      
+      --count int         (default 3)
+      --country string   
+      --name string       (default "bob")
+  -V, --verbose
+
+Constraints:
+  At least 1 of 'city', 'country' must be given
+  'name' must match regex "^[a-z]+$"
+  'count' must be >= 1
+  'count' must be < 10
+  'verbose' requires 'name'
+`
+)
+
+func TestArgConstraintsPassWhenSatisfied(t *testing.T) {
+	setupAndRunCode(t, setupConstraintsRsl, "--city", "Sydney", "--count", "9", "--name", "alice", "-V")
+	expected := `Sydney null alice 9
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestArgConstraintsShownInUsage(t *testing.T) {
+	setupAndRunCode(t, setupConstraintsRsl, "-h")
+	assertOnlyOutput(t, stdOutBuffer, constraintsUsage)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestArgConstraintAtLeast(t *testing.T) {
+	setupAndRunCode(t, setupConstraintsRsl, "--count", "2")
+	assertOutput(t, stdOutBuffer, constraintsUsage)
+	assertError(t, 1, "At least 1 of 'city', 'country' must be given, but got none\n")
+	resetTestState()
+}
+
+func TestArgConstraintRegex(t *testing.T) {
+	setupAndRunCode(t, setupConstraintsRsl, "--city", "Sydney", "--name", "Alice")
+	assertOutput(t, stdOutBuffer, constraintsUsage)
+	assertError(t, 1, "'name' must match regex \"^[a-z]+$\", but got \"Alice\"\n")
+	resetTestState()
+}
+
+func TestArgConstraintRange(t *testing.T) {
+	setupAndRunCode(t, setupConstraintsRsl, "--city", "Sydney", "--count", "10")
+	assertOutput(t, stdOutBuffer, constraintsUsage)
+	assertError(t, 1, "'count' must be < 10, but got 10\n")
+	resetTestState()
+}
+
+func TestArgConstraintRequires(t *testing.T) {
+	rsl := `
+args:
+	token string?
+	user string?
+	verbose V bool
+	token requires user
+	verbose requires token, user
+print(token)
+`
+	setupAndRunCode(t, rsl, "--token", "abc", "-V")
+	expected := `Usage:
+  test [token] [user] [--verbose] [flags]
+
+Flags:
+      --token string   
+      --user string    
+  -V, --verbose
+
+Constraints:
+  'token' requires 'user'
+  'verbose' requires 'token', 'user'
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertError(t, 1, "'token' requires 'user'\n'verbose' requires 'user'\n")
+	resetTestState()
+}
+
+func TestArgConstraintRequiresIgnoresFalseBools(t *testing.T) {
+	rsl := `
+args:
+	user string?
+	verbose V bool
+	verbose requires user
+print(verbose)
+`
+	setupAndRunCode(t, rsl, "--verbose=false")
+	assertOnlyOutput(t, stdOutBuffer, "false\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestArgConstraintOneOf(t *testing.T) {
+	rsl := `
+args:
+	yaml bool
+	csv bool
+	one_of yaml, csv
+print(yaml)
+`
+	setupAndRunCode(t, rsl, "--yaml", "--csv")
+	expected := `Usage:
+  test [--yaml] [--csv] [flags]
+
+Flags:
+      --csv    
+      --yaml
+
+Constraints:
+  Only one of 'yaml', 'csv' may be given
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertError(t, 1, "Only one of 'yaml', 'csv' may be given, but got 'yaml', 'csv'\n")
+	resetTestState()
+}
+
+func TestArgConstraintExactly(t *testing.T) {
+	rsl := `
+args:
+	a string?
+	b string?
+	c string?
+	exactly 2 a, b, c
+print(a, b, c)
+`
+	setupAndRunCode(t, rsl, "x", "y")
+	assertOnlyOutput(t, stdOutBuffer, "x y null\n")
+	assertNoErrors(t)
+	resetTestState()
+
+	setupAndRunCode(t, rsl, "x")
+	expected := `Usage:
+  test [a] [b] [c] [flags]
+
+Flags:
+      --a string   
+      --b string   
+      --c string
+
+Constraints:
+  Exactly 2 of 'a', 'b', 'c' must be given
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertError(t, 1, "Exactly 2 of 'a', 'b', 'c' must be given, but got 'a'\n")
+	resetTestState()
+}
+
+func TestArgConstraintNegatedRegexOnArray(t *testing.T) {
+	rsl := `
+args:
+	tags string[]
+	tags not regex "\s"
+print(tags)
+`
+	setupAndRunCode(t, rsl, "a,b c")
+	expected := `Usage:
+  test <tags> [flags]
+
+Flags:
+      --tags strings
+
+Constraints:
+  'tags' must not match regex "\\s"
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertError(t, 1, "'tags' must not match regex \"\\\\s\", but got \"b c\"\n")
+	resetTestState()
+}
+
+func TestArgConstraintNegativeFloatRange(t *testing.T) {
+	rsl := `
+args:
+	temp float
+	temp > -273.15
+print(temp)
+`
+	setupAndRunCode(t, rsl, "--temp", "-300")
+	expected := `Usage:
+  test <temp> [flags]
+
+Flags:
+      --temp float
+
+Constraints:
+  'temp' must be > -273.15
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertError(t, 1, "'temp' must be > -273.15, but got -300\n")
+	resetTestState()
+}
+
+func TestArgConstraintOnUndeclaredArg(t *testing.T) {
+	rsl := `
+args:
+	username string
+	usernmae regex "^[a-z]+$"
+print(username)
+`
+	setupAndRunCode(t, rsl, "alice")
+	expected := `error: Constraint refers to undeclared arg 'usernmae'
+ --> test:4:2
+4 | 	usernmae regex "^[a-z]+$"
+  | 	^^^^^^^^
+  = help: did you mean ` + "`username`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestArgConstraintOnWrongArgType(t *testing.T) {
+	rsl := `
+args:
+	count int
+	count regex "^[0-9]$"
+print(count)
+`
+	setupAndRunCode(t, rsl, "1")
+	expected := `error: Regex constraints only apply to string args, but 'count' is int
+ --> test:4:2
+4 | 	count regex "^[0-9]$"
+  | 	^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestArgConstraintErrorsReportedByCheck(t *testing.T) {
+	rsl := `
+args:
+	a string?
+	b string?
+	at_least 3 a, b
+print(a, b)
+`
+	setupAndRunCode(t, rsl, "check")
+	expected := `error: 'at_least 3' can never be satisfied by 2 args
+ --> test:5:11
+5 | 	at_least 3 a, b
+  | 	         ^
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertExitCode(t, 1)
+	resetTestState()
+}
@@ -0,0 +1,89 @@
+package testing
+
+import "testing"
+
+const (
+	setupEnumRsl = `
+args:
+	env string in ["prod", "staging"] # The environment.
+	regions r string[] in ["us", "eu"] = ["us"]
+print(env, regions)
+`
+	enumUsage = `Usage:
+  test <env> [regions] [flags]
+
+Flags:
+      --env string        The environment. (valid values: prod, staging)
+  -r, --regions strings   (valid values: us, eu) (default [us])
+`
+)
+
+func TestEnumArgAcceptsValidValue(t *testing.T) {
+	setupAndRunCode(t, setupEnumRsl, "staging", "-r", "us,eu")
+	expected := `staging [us, eu]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestEnumArgListsValuesInUsage(t *testing.T) {
+	setupAndRunCode(t, setupEnumRsl, "-h")
+	assertOnlyOutput(t, stdOutBuffer, enumUsage)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestEnumArgRejectsInvalidPositional(t *testing.T) {
+	setupAndRunCode(t, setupEnumRsl, "dev")
+	assertOutput(t, stdOutBuffer, enumUsage)
+	assertError(t, 1, "Invalid 'env' value: \"dev\". Valid values: prod, staging\n")
+	resetTestState()
+}
+
+func TestEnumArgRejectsInvalidFlag(t *testing.T) {
+	setupAndRunCode(t, setupEnumRsl, "--env", "dev")
+	assertOutput(t, stdOutBuffer, enumUsage)
+	assertError(t, 1, "Invalid 'env' value: \"dev\". Valid values: prod, staging\n")
+	resetTestState()
+}
+
+func TestEnumArgRejectsInvalidArrayElement(t *testing.T) {
+	setupAndRunCode(t, setupEnumRsl, "prod", "--regions", "us,ap")
+	assertOutput(t, stdOutBuffer, enumUsage)
+	assertError(t, 1, "Invalid 'regions' value: \"ap\". Valid values: us, eu\n")
+	resetTestState()
+}
+
+func TestEnumArgErrorsOnDefaultNotInValues(t *testing.T) {
+	rsl := `
+args:
+	env string in ["prod", "staging"] = "prd"
+print(env)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Default "prd" is not one of the values 'env' is restricted to
+ --> test:3:38
+3 | 	env string in ["prod", "staging"] = "prd"
+  | 	                                    ^^^^^
+  = help: did you mean ` + "`prod`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestEnumArgMustBeString(t *testing.T) {
+	rsl := `
+args:
+	count int in ["1", "2"]
+print(count)
+`
+	setupAndRunCode(t, rsl, "1")
+	expected := `error: Only string args may be restricted to a set of values, but 'count' is int
+ --> test:3:12
+3 | 	count int in ["1", "2"]
+  | 	          ^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -0,0 +1,160 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	setupFallbacksRsl = `
+args:
+	token string env "RAD_TEST_TOKEN" # API token.
+	org string = "acme"
+	tags string[]?
+	count int env "RAD_TEST_COUNT" = 1
+print(token, org, tags, count)
+`
+)
+
+// writeArgConfig writes the user config for the 'test' script, returning its path.
+func writeArgConfig(t *testing.T, contents string) string {
+	t.Helper()
+	configDir := t.TempDir()
+	testEnvVars.Set("XDG_CONFIG_HOME", configDir)
+	path := filepath.Join(configDir, "rad", "test.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestArgFallsBackToEnvVar(t *testing.T) {
+	testEnvVars.Set("RAD_TEST_TOKEN", "from-env")
+	testEnvVars.Set("XDG_CONFIG_HOME", t.TempDir())
+	setupAndRunCode(t, setupFallbacksRsl)
+	expected := `from-env acme null 1
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestArgPositionalTakesPrecedenceOverEnvVar(t *testing.T) {
+	testEnvVars.Set("RAD_TEST_TOKEN", "from-env")
+	testEnvVars.Set("XDG_CONFIG_HOME", t.TempDir())
+	setupAndRunCode(t, setupFallbacksRsl, "from-positional")
+	expected := `from-positional acme null 1
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestArgFlagTakesPrecedenceOverEnvVar(t *testing.T) {
+	testEnvVars.Set("RAD_TEST_TOKEN", "from-env")
+	testEnvVars.Set("XDG_CONFIG_HOME", t.TempDir())
+	setupAndRunCode(t, setupFallbacksRsl, "--token", "from-flag")
+	expected := `from-flag acme null 1
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestArgFallsBackToConfigAfterEnvVar(t *testing.T) {
+	writeArgConfig(t, `
+token: from-config
+org: widgets
+tags: [a, b]
+count: 4
+`)
+	testEnvVars.Set("RAD_TEST_COUNT", "9")
+	setupAndRunCode(t, setupFallbacksRsl)
+	expected := `from-config widgets [a, b] 9
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestArgFallbackSourcesShownInUsage(t *testing.T) {
+	path := writeArgConfig(t, "org: widgets\n")
+	testEnvVars.Set("RAD_TEST_TOKEN", "from-env")
+	setupAndRunCode(t, setupFallbacksRsl, "-h")
+	expected := `Usage:
+  test <token> [org] [tags] [count] [flags]
+
+Flags:
+      --count int      (env: RAD_TEST_COUNT) (default 1)
+      --org string     (from ` + path + `) (default "widgets")
+      --tags strings   
+      --token string   API token. (env: RAD_TEST_TOKEN) (from $RAD_TEST_TOKEN) (default "from-env")
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestArgConfigFoundUnderHomeFromEnv(t *testing.T) {
+	home := t.TempDir()
+	testEnvVars.Set("HOME", home)
+	path := filepath.Join(home, ".config", "rad", "test.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("org: widgets\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	setupAndRunCode(t, setupFallbacksRsl, "-h")
+	expected := `Usage:
+  test <token> [org] [tags] [count] [flags]
+
+Flags:
+      --count int      (env: RAD_TEST_COUNT) (default 1)
+      --org string     (from ~/.config/rad/test.yaml) (default "widgets")
+      --tags strings   
+      --token string   API token. (env: RAD_TEST_TOKEN)
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestArgFallbackErrorsOnInvalidValue(t *testing.T) {
+	testEnvVars.Set("RAD_TEST_TOKEN", "from-env")
+	testEnvVars.Set("RAD_TEST_COUNT", "many")
+	testEnvVars.Set("XDG_CONFIG_HOME", t.TempDir())
+	setupAndRunCode(t, setupFallbacksRsl)
+	expected := `Usage:
+  test <token> [org] [tags] [count] [flags]
+
+Flags:
+      --count int      (env: RAD_TEST_COUNT) (default 1)
+      --org string      (default "acme")
+      --tags strings   
+      --token string   API token. (env: RAD_TEST_TOKEN) (from $RAD_TEST_TOKEN) (default "from-env")
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertError(t, 1, "Invalid 'count' value from $RAD_TEST_COUNT: expected int, got \"many\"\n")
+	resetTestState()
+}
+
+func TestArgFallbackErrorsOnListForSingleValue(t *testing.T) {
+	path := writeArgConfig(t, "token: [a, b]\n")
+	setupAndRunCode(t, setupFallbacksRsl)
+	assertOutput(t, stdOutBuffer, `Usage:
+  test <token> [org] [tags] [count] [flags]
+
+Flags:
+      --count int      (env: RAD_TEST_COUNT) (default 1)
+      --org string      (default "acme")
+      --tags strings   
+      --token string   API token. (env: RAD_TEST_TOKEN)
+`)
+	assertError(t, 1, "Invalid 'token' value from "+path+": expected a single string, got a list\n")
+	resetTestState()
+}
@@ -0,0 +1,34 @@
+package testing
+
+import "testing"
+
+func TestPositionalIntArg(t *testing.T) {
+	rsl := `
+args:
+	count int
+print(count + 1)
+`
+	setupAndRunCode(t, rsl, "41")
+	assertOnlyOutput(t, stdOutBuffer, "42\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestPositionalBoolArrayArg(t *testing.T) {
+	rsl := `
+args:
+	flags bool[]
+print(flags)
+`
+	setupAndRunCode(t, rsl, "true,0")
+	assertOnlyOutput(t, stdOutBuffer, "[true, false]\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestHelpForScriptFile(t *testing.T) {
+	setupAndRunArgs(t, "./rads/print.rad", "--help")
+	assertOnlyOutput(t, stdOutBuffer, "Usage:\n  print.rad\n")
+	assertNoErrors(t)
+	resetTestState()
+}
@@ -0,0 +1,242 @@
+package testing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretArgRedactedWhenPrinted(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+print(token)
+print("Bearer {token}")
+pprint(token)
+`
+	setupAndRunCode(t, rsl, "abc123")
+	expected := `****
+Bearer ****
+****
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgRevealedInRequest(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+url = "https://google.com?token={token}"
+Id = json[].id
+rad url:
+	fields Id
+`
+	setupAndRunCode(t, rsl, "abc123", "--MOCK-RESPONSE", "token=abc123:./responses/id_name.json", "--NO-COLOR")
+	expected := "Id \n1   \n2   \n"
+	assertOutput(t, stdOutBuffer, expected)
+	assertOutput(t, stdErrBuffer, "Mocking response for url (matched \"token=abc123\"): https://google.com?token=****\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgRedactedInErrors(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+	token regex "^ghp_"
+print(token)
+`
+	setupAndRunCode(t, rsl, "abc123")
+	expected := `Usage:
+  test <token> [flags]
+
+Flags:
+      --token string
+
+Constraints:
+  'token' must match regex "^ghp_"
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertError(t, 1, "'token' must match regex \"^ghp_\", but got \"****\"\n")
+	resetTestState()
+}
+
+func TestSecretArgDefaultAndFallbackHiddenFromUsage(t *testing.T) {
+	testEnvVars.Set("RAD_TEST_TOKEN", "from-env")
+	testEnvVars.Set("XDG_CONFIG_HOME", t.TempDir())
+	rsl := `
+args:
+	token string secret env "RAD_TEST_TOKEN"
+	password string secret = "hunter2"
+print(token, password)
+`
+	setupAndRunCode(t, rsl, "-h")
+	expected := `Usage:
+  test <token> [password] [flags]
+
+Flags:
+      --password string   
+      --token string      (env: RAD_TEST_TOKEN) (from $RAD_TEST_TOKEN)
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgNotExportedForShell(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+	region string
+`
+	setupAndRunCode(t, rsl, "abc123", "us-east-1", "--SHELL")
+	assertOnlyOutput(t, stdOutBuffer, "export region=\"us-east-1\"\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgMissingWhenNotInteractive(t *testing.T) {
+	rsl := `
+args:
+	region string
+	token string secret
+print(token)
+`
+	setupAndRunCode(t, rsl, "us-east-1")
+	expected := `Usage:
+  test <region> <token> [flags]
+
+Flags:
+      --region string   
+      --token string
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertError(t, 1, "Missing required arguments: [token]\n")
+	resetTestState()
+}
+
+func TestSecretArgMustBeString(t *testing.T) {
+	rsl := `
+args:
+	pin int secret
+print(pin)
+`
+	setupAndRunCode(t, rsl, "1234")
+	expected := `error: Only string args may be secret, but 'pin' is int
+ --> test:3:10
+3 | 	pin int secret
+  | 	        ^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestSecretArgRedactedWhenTransformed(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+print(upper(token))
+print(replace(token, "3", "x"))
+print("id-" + token, "{upper(token)}!")
+print(split(token, "1"))
+print(len(token), type_of(token), token == "abc123")
+`
+	setupAndRunCode(t, rsl, "abc123")
+	expected := `****
+****
+id-**** ****!
+[****, ****]
+6 string true
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgRedactedWhenTransformedInDebug(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+debug(upper(token))
+`
+	setupAndRunCode(t, rsl, "xyz789", "--DEBUG", "--RAD-DEBUG")
+	output := stdOutBuffer.String() + stdErrBuffer.String()
+	stdOutBuffer.Reset()
+	stdErrBuffer.Reset()
+	if strings.Contains(output, "XYZ789") || strings.Contains(output, "xyz789") {
+		t.Errorf("Expected the secret to be redacted, got:\n%s", output)
+	}
+	if !strings.Contains(output, "DEBUG: ****\n") {
+		t.Errorf("Expected the redacted debug message, got:\n%s", output)
+	}
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgTransformedNotExportedForShell(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+upper_token = upper(token)
+replaced = replace(token, "3", "x")
+length = len(token)
+`
+	setupAndRunCode(t, rsl, "abc123", "--SHELL")
+	assertOnlyOutput(t, stdOutBuffer, "export length=\"6\"\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgTransformedRevealedInRequest(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+url = "https://google.com?token={upper(token)}"
+Id = json[].id
+rad url:
+	fields Id
+`
+	setupAndRunCode(t, rsl, "abc123", "--MOCK-RESPONSE", "token=ABC123:./responses/id_name.json", "--NO-COLOR")
+	expected := "Id \n1   \n2   \n"
+	assertOutput(t, stdOutBuffer, expected)
+	assertOutput(t, stdErrBuffer, "Mocking response for url (matched \"token=ABC123\"): https://google.com?token=****\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgOnlyRedactsValuesMadeFromIt(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+print("count", 10, len(token))
+`
+	setupAndRunCode(t, rsl, "1")
+	assertOnlyOutput(t, stdOutBuffer, "count 10 1\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgDoesNotStopUnrelatedShellExports(t *testing.T) {
+	rsl := `
+args:
+	token string secret
+count = 10
+`
+	setupAndRunCode(t, rsl, "1", "--SHELL")
+	assertOnlyOutput(t, stdOutBuffer, "export count=\"10\"\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSecretArgRedactedInEnumError(t *testing.T) {
+	rsl := `
+args:
+	token string secret in ["a", "b"]
+print(token)
+`
+	setupAndRunCode(t, rsl, "abc123")
+	assertOutput(t, stdOutBuffer, "Usage:\n  test <token> [flags]\n\nFlags:\n      --token string   (valid values: a, b)\n")
+	assertError(t, 1, "Invalid 'token' value: \"****\". Valid values: a, b\n")
+	resetTestState()
+}
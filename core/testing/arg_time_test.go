@@ -0,0 +1,155 @@
+package testing
+
+import "testing"
+
+const (
+	setupTimeArgsRsl = `
+args:
+	since date
+	until datetime = "now"
+	window duration = "1d"
+print(since, until, window)
+`
+)
+
+func TestTimeArgsIso(t *testing.T) {
+	setupAndRunCode(t, setupTimeArgsRsl, "2024-02-28", "--until", "2024-03-01T09:30:00+10:00", "--window", "90m")
+	assertOnlyOutput(t, stdOutBuffer, "2024-02-28 2024-03-01T09:30:00+10:00 1h30m\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTimeArgsDefaultsRelativeToClock(t *testing.T) {
+	setupAndRunCode(t, setupTimeArgsRsl, "today")
+	assertOnlyOutput(t, stdOutBuffer, "2019-12-13 2019-12-13T14:15:16Z 1d\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTimeArgsRelative(t *testing.T) {
+	// negative offsets must be given with '=', else they're taken as flags
+	setupAndRunCode(t, setupTimeArgsRsl, "--since=-7d", "--until", "yesterday")
+	assertOnlyOutput(t, stdOutBuffer, "2019-12-06 2019-12-12T00:00:00Z 1d\n")
+	assertNoErrors(t)
+	resetTestState()
+
+	setupAndRunCode(t, setupTimeArgsRsl, "tomorrow", "--until=-1h30m")
+	assertOnlyOutput(t, stdOutBuffer, "2019-12-14 2019-12-13T12:45:16Z 1d\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTimeArgsDurationFormats(t *testing.T) {
+	rsl := `
+args:
+	window duration
+print(window)
+`
+	for input, expected := range map[string]string{
+		"2w":          "14d\n",
+		"1d12h":       "1d12h\n",
+		"1.5h":        "1h30m\n",
+		"-45s":        "-45s\n",
+		"250ms":       "250ms\n",
+		"P1DT2H30M":   "1d2h30m\n",
+		"PT90M":       "1h30m\n",
+		"P2W":         "14d\n",
+		"1h0m30.5s":   "1h30.5s\n",
+		"-P1DT0.001S": "-1d0.001s\n",
+	} {
+		setupAndRunCode(t, rsl, "--window="+input)
+		assertOnlyOutput(t, stdOutBuffer, expected)
+		assertNoErrors(t)
+		resetTestState()
+	}
+}
+
+func TestTimeArgArithmetic(t *testing.T) {
+	rsl := `
+args:
+	since date
+	until datetime
+	window duration
+print(since + window)
+print(since + window / 4)
+print(until - window)
+print(until - since)
+print(window * 2, 3 * window, window / window)
+print(since < until, since + window > until)
+print("window: " + window)
+`
+	setupAndRunCode(t, rsl, "2024-02-28", "2024-03-01T09:30:00Z", "2d")
+	expected := `2024-03-01
+2024-02-28T12:00:00Z
+2024-02-28T09:30:00Z
+2d9h30m
+4d 6d 1
+true false
+window: 2d
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTimeArgInterpolation(t *testing.T) {
+	rsl := `
+args:
+	since date = "-1d"
+url = "https://api.example.com/events?since={since}"
+print(url)
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "https://api.example.com/events?since=2019-12-12\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTimeArgInvalidValue(t *testing.T) {
+	setupAndRunCode(t, setupTimeArgsRsl, "last week")
+	expected := `Usage:
+  test <since> [until] [window] [flags]
+
+Flags:
+      --since date        
+      --until datetime     (default now)
+      --window duration    (default 1d)
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertError(t, 1, "Invalid 'since' value: expected a date like 2024-01-31, 'today', 'yesterday', or an offset like -7d, got \"last week\"\n")
+	resetTestState()
+}
+
+func TestTimeArgInvalidDefaultReportedByCheck(t *testing.T) {
+	rsl := `
+args:
+	window duration = "1 month"
+print(window)
+`
+	setupAndRunCode(t, rsl, "check")
+	expected := `error: Invalid default for 'window': expected a duration like 1h30m, 7d, or P1DT12H, got "1 month"
+ --> test:3:20
+3 | 	window duration = "1 month"
+  | 	                  ^^^^^^^^^
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertExitCode(t, 1)
+	resetTestState()
+}
+
+func TestTimeArgInvalidOperator(t *testing.T) {
+	rsl := `
+args:
+	since date
+	until date
+print(since + until)
+`
+	setupAndRunCode(t, rsl, "today", "tomorrow")
+	expected := `error: Invalid binary operator '+' for date, date
+ --> test:5:13
+5 | print(since + until)
+  |             ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
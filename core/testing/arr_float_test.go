@@ -31,6 +31,11 @@ a float[] = [1.1, 2.2, 3.3]
 print(a + ["4.4"])
 `
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L3/9 on '+': Cannot join two arrays of different types: float[], mixed array\n")
+	expected := `error: Cannot join two arrays of different types: float[], mixed array
+ --> test:3:9
+3 | print(a + ["4.4"])
+  |         ^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
@@ -27,6 +27,11 @@ a int[] = [1, 2, 3]
 print(a + ["4"])
 `
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L3/9 on '+': Cannot join two arrays of different types: int[], mixed array\n")
+	expected := `error: Cannot join two arrays of different types: int[], mixed array
+ --> test:3:9
+3 | print(a + ["4"])
+  |         ^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
@@ -29,6 +29,11 @@ a string[] = ["a", "b", "c"]
 print(a + [1])
 `
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L3/9 on '+': Cannot join two arrays of different types: string[], mixed array\n")
+	expected := `error: Cannot join two arrays of different types: string[], mixed array
+ --> test:3:9
+3 | print(a + [1])
+  |         ^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
@@ -0,0 +1,127 @@
+package testing
+
+import "testing"
+
+func TestCheckNoIssues(t *testing.T) {
+	rsl := `
+a = 1
+print(a)
+`
+	setupAndRunCode(t, rsl, "check")
+	assertOnlyOutput(t, stdOutBuffer, "test: No issues found\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestCheckDoesNotRunScript(t *testing.T) {
+	rsl := `
+print("should not print")
+exit(2)
+`
+	setupAndRunCode(t, rsl, "check")
+	assertOnlyOutput(t, stdOutBuffer, "test: No issues found\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestCheckReportsErrorsAndWarningsInOrder(t *testing.T) {
+	rsl := `
+args:
+	name string
+	verbose bool
+a = upper(name, 1)
+exit()
+print(b)
+`
+	setupAndRunCode(t, rsl, "check")
+	expected := `warning: Arg 'verbose' is never used
+ --> test:4:2
+4 | 	verbose bool
+  | 	^^^^^^^
+
+error: upper() takes exactly 1 argument, got 2
+ --> test:5:5
+5 | a = upper(name, 1)
+  |     ^^^^^
+  = help: usage: ` + "`upper(value)`" + `
+
+warning: Code after exit() is unreachable
+ --> test:7:1
+7 | print(b)
+  | ^^^^^
+
+error: Undefined variable referenced: b
+ --> test:7:7
+7 | print(b)
+  |       ^
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertExitCode(t, 1)
+	resetTestState()
+}
+
+func TestCheckWarnsOfShadowedVariables(t *testing.T) {
+	rsl := `
+item = "first"
+for item in ["a", "b"]:
+	print(item)
+try:
+	print(item)
+catch item:
+	print(item)
+`
+	setupAndRunCode(t, rsl, "check")
+	expected := `warning: 'item' shadows an existing variable, which it will overwrite
+ --> test:3:5
+3 | for item in ["a", "b"]:
+  |     ^^^^
+
+warning: 'item' shadows an existing variable, which it will overwrite
+ --> test:7:7
+7 | catch item:
+  |       ^^^^
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestCheckWarnsOfFieldsMissingFromFieldsStmt(t *testing.T) {
+	rsl := `
+url = "http://localhost"
+Name = json[].name
+rad url:
+	fields Name
+	sort Age
+
+	Age:
+		truncate 5
+`
+	setupAndRunCode(t, rsl, "check")
+	expected := `warning: Field 'Age' is not in the fields statement
+ --> test:6:7
+6 | 	sort Age
+  | 	     ^^^
+
+warning: Field 'Age' is not in the fields statement
+ --> test:8:2
+8 | 	Age:
+  | 	^^^
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestCheckCountsArgsInConstraintsAsUsed(t *testing.T) {
+	rsl := `
+args:
+	name string
+	other string?
+	other requires name
+`
+	setupAndRunCode(t, rsl, "check")
+	assertOnlyOutput(t, stdOutBuffer, "test: No issues found\n")
+	assertNoErrors(t)
+	resetTestState()
+}
@@ -0,0 +1,122 @@
+package testing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompletionScriptGenerated(t *testing.T) {
+	setupAndRunArgs(t, "completion", "bash")
+	script := stdOutBuffer.String()
+	if !strings.HasPrefix(script, "# bash completion V2 for rad") {
+		t.Errorf("Expected a bash completion script, got: %q", script)
+	}
+	stdOutBuffer.Reset()
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestCompletionCompletesFlagNames(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/completion.rad", "--")
+	expected := `--count
+--env	(valid values: prod, staging)
+--help	help for completion.rad
+--name
+--verbose
+--version	version for completion.rad
+:4
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveNoFileComp\n")
+	resetTestState()
+}
+
+func TestCompletionCompletesEnumFlagValues(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/completion.rad", "--env", "st")
+	expected := `staging
+:4
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveNoFileComp\n")
+	resetTestState()
+}
+
+func TestCompletionCompletesEnumPositionals(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/completion.rad", "bob", "")
+	expected := `prod
+staging
+:4
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveNoFileComp\n")
+	resetTestState()
+}
+
+func TestCompletionCompletesResourceKeys(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/completion.rad", "")
+	expected := `alice
+bob
+robert
+:4
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveNoFileComp\n")
+	resetTestState()
+}
+
+func TestCompletionSkipsArgsGivenAsFlags(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/completion.rad", "--name", "bob", "")
+	expected := `prod
+staging
+:4
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveNoFileComp\n")
+	resetTestState()
+}
+
+func TestCompletionCompletesScriptPathsAsFiles(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/comp")
+	assertOutput(t, stdOutBuffer, ":0\n")
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveDefault\n")
+	resetTestState()
+}
+
+func TestCompletionCompletesPathsWithExtensions(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/completion_paths.rad", "")
+	expected := `yaml
+yml
+:8
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveFilterFileExt\n")
+	resetTestState()
+}
+
+func TestCompletionCompletesPaths(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/completion_paths.rad", "--input", "")
+	assertOutput(t, stdOutBuffer, ":0\n")
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveDefault\n")
+	resetTestState()
+}
+
+func TestCompletionCompletesDirs(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/completion_paths.rad", "a.yaml", "b.txt", "")
+	assertOutput(t, stdOutBuffer, ":16\n")
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveFilterDirs\n")
+	resetTestState()
+}
+
+func TestCompletionDoesNotCompleteStringsAsPaths(t *testing.T) {
+	setupAndRunArgs(t, "__complete", "./rads/completion_paths.rad", "--name", "")
+	assertOutput(t, stdOutBuffer, ":4\n")
+	assertOutput(t, stdErrBuffer, "Completion ended with directive: ShellCompDirectiveNoFileComp\n")
+	resetTestState()
+}
+
+func TestPathArgsAreStrings(t *testing.T) {
+	setupAndRunArgs(t, "./rads/completion_paths.rad", "a.yaml", "b.txt", "out", "bob")
+	assertOnlyOutput(t, stdOutBuffer, "a.yaml b.txt out bob\n")
+	assertNoErrors(t)
+	resetTestState()
+}
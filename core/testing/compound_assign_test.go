@@ -93,7 +93,12 @@ func TestCompoundSubtractFromArrayErrors(t *testing.T) {
 	rsl := `a = [1]
 a -= 2`
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L2/4 on '-=': Invalid binary operator for mixed array, int\n")
+	expected := `error: Invalid binary operator for mixed array, int
+ --> test:2:3
+2 | a -= 2
+  |   ^^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
 
@@ -101,7 +106,12 @@ func TestCompoundDivideFromArrayErrors(t *testing.T) {
 	rsl := `a = [1]
 a /= 2`
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L2/4 on '/=': Invalid binary operator for mixed array, int\n")
+	expected := `error: Invalid binary operator for mixed array, int
+ --> test:2:3
+2 | a /= 2
+  |   ^^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
 
@@ -109,6 +119,11 @@ func TestCompoundMultiplyFromArrayErrors(t *testing.T) {
 	rsl := `a = [1]
 a *= 2`
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L2/4 on '*=': Invalid binary operator for mixed array, int\n")
+	expected := `error: Invalid binary operator for mixed array, int
+ --> test:2:3
+2 | a *= 2
+  |   ^^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
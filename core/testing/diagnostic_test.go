@@ -0,0 +1,84 @@
+package testing
+
+import "testing"
+
+func TestErrorSuggestsSimilarVariable(t *testing.T) {
+	rsl := `
+Author = "Alice"
+print(Autor)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Undefined variable referenced: Autor
+ --> test:3:7
+3 | print(Autor)
+  |       ^^^^^
+  = help: did you mean ` + "`Author`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestErrorSuggestsSimilarFunction(t *testing.T) {
+	rsl := `
+a = uper("hi")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Unknown function: uper
+ --> test:2:5
+2 | a = uper("hi")
+  |     ^^^^
+  = help: did you mean ` + "`upper`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestRuntimeErrorSuggestsSimilarModuleVariable(t *testing.T) {
+	rsl := `
+import "rads/modules/common.rad"
+print(common.retires)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Module 'common' has no variable 'retires'
+ --> test:3:14
+3 | print(common.retires)
+  |              ^^^^^^^
+  = help: did you mean ` + "`retries`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestErrorNotesVariableDefinedInEarlierBlock(t *testing.T) {
+	rsl := `
+if true:
+    greeting = "hi"
+print(greeting)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Undefined variable referenced: greeting
+ --> test:4:7
+4 | print(greeting)
+  |       ^^^^^^^^
+  = note: 'greeting' is defined in an earlier block, and isn't visible outside of it
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestErrorExcerptKeepsTabsAligned(t *testing.T) {
+	rsl := `
+args:
+	count int
+if count > 0:
+	b = count + true
+`
+	setupAndRunCode(t, rsl, "1")
+	expected := `error: Invalid binary operand types: int, bool
+ --> test:5:12
+5 | 	b = count + true
+  | 	          ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -18,3 +18,99 @@ it works!!!
 	assertNoErrors(t)
 	resetTestState()
 }
+
+func TestModuloAndPower(t *testing.T) {
+	rsl := `
+print(7 % 3, -7 % 3, 7.5 % 2, 7 % 2.5)
+print(2 ** 10, 2.0 ** -1, 2.0 ** 3, 9 ** 0.5)
+print(-2 ** 2, 2 ** 3 ** 2, 2 * 3 ** 2)
+print(7 / 2, 7.0 / 2, 10 % 4 * 2)
+a = 10
+a %= 4
+print(a)
+`
+	setupAndRunCode(t, rsl)
+	expected := `1 -1 1.5 2
+1024 0.5 8 3
+-4 512 18
+3 3.5 4
+2
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestIntPowerOfNegativeIntErrors(t *testing.T) {
+	rsl := `
+print(2 ** -1)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: 2 ** -1 is not an int, as the exponent is negative
+ --> test:2:9
+2 | print(2 ** -1)
+  |         ^^
+  = help: use a float to allow it e.g. ` + "`2.0 ** -1`" + `
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestDivisionByZero(t *testing.T) {
+	rsl := `
+a = 5
+print(a / 0)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Division by zero
+ --> test:3:9
+3 | print(a / 0)
+  |         ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestFloatDivisionByZero(t *testing.T) {
+	rsl := `
+a = 5.5
+a /= 0.0
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Division by zero
+ --> test:3:3
+3 | a /= 0.0
+  |   ^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestModuloByZero(t *testing.T) {
+	rsl := `
+print(5 % 0)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Modulo by zero
+ --> test:2:9
+2 | print(5 % 0)
+  |         ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestIntPowerOverflow(t *testing.T) {
+	rsl := `
+print(2 ** 63)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: 2 ** 63 is too large for an int
+ --> test:2:9
+2 | print(2 ** 63)
+  |         ^^
+  = help: use a float to allow it e.g. ` + "`2.0 ** 63`" + `
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
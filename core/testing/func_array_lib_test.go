@@ -0,0 +1,265 @@
+package testing
+
+import "testing"
+
+func TestSort(t *testing.T) {
+	rsl := `
+print(sort([3, 1, 2]))
+print(sort(["b", "C", "a"], direction="desc"))
+print(sort([2.5, 1, 3]))
+print(sort(["ccc", "a", "bb", "dd"], "len"))
+print(sort(["Bob", "alice", "Carol"], "lower", "desc"))
+rows = [["alice", 30], ["bob", 25], ["carol", 35]]
+print(sort(rows, 1))
+print(sort(["x", "y"]) + "z")
+`
+	setupAndRunCode(t, rsl)
+	expected := `[1, 2, 3]
+[b, a, C]
+[1, 2.5, 3]
+[a, bb, dd, ccc]
+[Carol, Bob, alice]
+[[bob, 25], [alice, 30], [carol, 35]]
+[x, y, z]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSortBadDirection(t *testing.T) {
+	rsl := `
+print(sort([1, 2], direction="ascc"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: sort() direction must be "asc" or "desc", got "ascc"
+ --> test:2:7
+2 | print(sort([1, 2], direction="ascc"))
+  |       ^^^^
+  = help: did you mean ` + "`asc`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestSortMixedTypes(t *testing.T) {
+	rsl := `
+print(sort([1, "a"]))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: sort() cannot compare a string with an int
+ --> test:2:7
+2 | print(sort([1, "a"]))
+  |       ^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestReverseUniq(t *testing.T) {
+	rsl := `
+print(reverse([1, 2, 3]), reverse("héllo"))
+print(uniq(["a", "b", "a", "c", "b"]))
+print(uniq([1, 1.0, 2, [1, 2], [1, 2]]))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[3, 2, 1] olléh
+[a, b, c]
+[1, 2, [1, 2]]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestFilterMap(t *testing.T) {
+	rsl := `
+names = ["alice", "bob", "anna", ""]
+print(filter(names))
+print(filter(names, "starts_with", "a"))
+print(map(names, "upper"))
+print(map([1, 22, 333], "pad_left", 4, "0"))
+print(map(filter(names), "len"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[alice, bob, anna]
+[alice, anna]
+[ALICE, BOB, ANNA, ]
+[0001, 0022, 0333]
+[5, 3, 4]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestMapUnknownFunction(t *testing.T) {
+	rsl := `
+print(map(["a"], "uper"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: map() got unknown function "uper"
+ --> test:2:7
+2 | print(map(["a"], "uper"))
+  |       ^^^
+  = help: did you mean ` + "`upper`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestMapFunctionWrongArgs(t *testing.T) {
+	rsl := `
+print(map(["a"], "pad_left"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: map() calls pad_left(), which takes 2 to 3 arguments, got 1
+ --> test:2:7
+2 | print(map(["a"], "pad_left"))
+  |       ^^^
+  = help: usage: ` + "`pad_left(value, width, pad?)`" + `
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestMapVoidFunction(t *testing.T) {
+	rsl := `
+print(map(["a"], "print"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: map() needs a function that returns a value, but print() does not
+ --> test:2:7
+2 | print(map(["a"], "print"))
+  |       ^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestZipFlattenChunk(t *testing.T) {
+	rsl := `
+print(zip(["a", "b", "c"], [1, 2]))
+print(flatten([[1, 2], [3, [4, 5]], 6]))
+print(flatten([[1, [2, [3]]]], 5))
+print(chunk([1, 2, 3, 4, 5], 2))
+print(chunk([], 3))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[[a, 1], [b, 2]]
+[1, 2, 3, [4, 5], 6]
+[1, 2, 3]
+[[1, 2], [3, 4], [5]]
+[]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestChunkSizeZero(t *testing.T) {
+	rsl := `
+print(chunk([1, 2], 0))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: chunk() size must be positive, got 0
+ --> test:2:7
+2 | print(chunk([1, 2], 0))
+  |       ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestAggregates(t *testing.T) {
+	rsl := `
+print(sum([1, 2, 3]), sum([1, 2.5]), sum([]))
+print(avg([1, 2, 3, 4]))
+print(min([3, 1.5, 2]), max([3, 1.5, 2]))
+print(min(["pear", "apple"]), max(["2024-01-01", "2023-12-31"]))
+`
+	setupAndRunCode(t, rsl)
+	expected := `6 3.5 0
+2.5
+1.5 3
+apple 2024-01-01
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSumNonNumbers(t *testing.T) {
+	rsl := `
+print(sum([1, "2"]))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: sum() takes an array of numbers, but it contains a string
+ --> test:2:7
+2 | print(sum([1, "2"]))
+  |       ^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestMaxEmpty(t *testing.T) {
+	rsl := `
+print(max([]))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: max() needs a non-empty array
+ --> test:2:7
+2 | print(max([]))
+  |       ^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestCountByGroupBy(t *testing.T) {
+	rsl := `
+print(count_by(["a", "b", "a", "c", "a"]))
+print(count_by(["apple", "Avocado", "banana"], "lower"))
+print(group_by(["aa", "b", "cc", "d"], "len"))
+rows = [["alice", "eng"], ["bob", "ops"], ["carol", "eng"]]
+for group in group_by(rows, 1):
+    print(group[0], len(group[1]))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[[a, 3], [b, 1], [c, 1]]
+[[apple, 1], [avocado, 1], [banana, 1]]
+[[2, [aa, cc]], [1, [b, d]]]
+eng 2
+ops 1
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestGroupByKeyOutOfBounds(t *testing.T) {
+	rsl := `
+print(group_by([["a", 1], ["b"]], 1))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: group_by() key 1 is out of bounds for [b] (length 1)
+ --> test:2:7
+2 | print(group_by([["a", 1], ["b"]], 1))
+  |       ^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestArrayIndexOf(t *testing.T) {
+	rsl := `
+print(index_of(["a", "b", "c"], "c"), index_of([1, 2], 2.0), index_of([[1], [2]], [2]), index_of([1], 5))
+`
+	setupAndRunCode(t, rsl)
+	expected := `2 1 1 -1
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
@@ -0,0 +1,205 @@
+package testing
+
+import "testing"
+
+func TestIntFloatStrBool(t *testing.T) {
+	rsl := `
+print(int("42") + 1, int(" -7 "), int(3.9), int(-3.9), int(true))
+print(float("2.5") * 2, float(3), float("1e3"))
+print(str(42) + "!", str([1, 2]), str(2.50))
+print(bool("yes"), bool("False"), bool(0), bool([1]), bool(""))
+`
+	setupAndRunCode(t, rsl)
+	expected := `43 -7 3 -3 1
+5 3 1000
+42! [1, 2] 2.5
+true false false true false
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestIntFromInvalidString(t *testing.T) {
+	rsl := `
+print(int("forty"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: int() cannot convert "forty" to an int
+ --> test:2:7
+2 | print(int("forty"))
+  |       ^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestIntFromFloatString(t *testing.T) {
+	rsl := `
+print(int("3.7"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: int() cannot convert "3.7" to an int
+ --> test:2:7
+2 | print(int("3.7"))
+  |       ^^^
+  = help: to truncate it, convert it to a float first e.g. ` + "`int(float(\"3.7\"))`" + `
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestFloatFromArray(t *testing.T) {
+	rsl := `
+print(float([1]))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: float() cannot convert an array to a float
+ --> test:2:7
+2 | print(float([1]))
+  |       ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestBoolFromInvalidString(t *testing.T) {
+	rsl := `
+print(bool("maybe"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: bool() cannot convert "maybe" to a bool
+ --> test:2:7
+2 | print(bool("maybe"))
+  |       ^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTypeOf(t *testing.T) {
+	rsl := `
+print(type_of(1), type_of(1.5), type_of("a"), type_of(true), type_of(null))
+print(type_of(split("a,b", ",")), type_of([1, "a"]), type_of(today()), type_of(parse_duration("1h")))
+`
+	setupAndRunCode(t, rsl)
+	expected := `int float string bool null
+string[] mixed array date duration
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestParseJson(t *testing.T) {
+	rsl := `
+print(parse_json('[1, 2.5, "a", true, null]'))
+print(parse_json("42") + 1)
+blob = '\{"name": "alice", "langs": ["go", "rsl"], "address": \{"city": "Paris"}}'
+print(parse_json(blob, "name"), parse_json(blob, "langs.1"), parse_json(blob, "address.city"))
+print(parse_json(blob, "address"))
+print(len(parse_json(blob, "langs")))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[1, 2.5, a, true, null]
+43
+alice rsl Paris
+{"city":"Paris"}
+2
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestParseJsonInvalid(t *testing.T) {
+	rsl := `
+print(parse_json("[1, 2"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: parse_json() got invalid JSON: unexpected EOF
+ --> test:2:7
+2 | print(parse_json("[1, 2"))
+  |       ^^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestParseJsonMissingKey(t *testing.T) {
+	rsl := `
+print(parse_json('\{"name": "alice"}', "nam"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: parse_json() path "nam": no key "nam"
+ --> test:2:7
+2 | print(parse_json('\{"name": "alice"}', "nam"))
+  |       ^^^^^^^^^^
+  = help: did you mean ` + "`name`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestToJson(t *testing.T) {
+	rsl := `
+print(to_json([1, "a<b", true, null, 2.5]))
+print(to_json("hi"), to_json(3), to_json(today()))
+people = [parse_json('\{"name": "bob", "age": 30}'), "not an object"]
+print(to_json(people))
+print(to_json(["a", ["b"]], 2))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[1,"a<b",true,null,2.5]
+"hi" 3 "2019-12-13"
+[{"age":30,"name":"bob"},"not an object"]
+[
+  "a",
+  [
+    "b"
+  ]
+]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestToJsonKeepsJsonTextAsString(t *testing.T) {
+	rsl := `
+text = '\{"name": "bob"}'
+print(to_json(text))
+print(parse_json(to_json(text)) == text)
+print(to_json([text, parse_json(text)]))
+`
+	setupAndRunCode(t, rsl)
+	expected := `"{\"name\": \"bob\"}"
+true
+["{\"name\": \"bob\"}",{"name":"bob"}]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestToJsonKeepsTextOfParsedObjectAsString(t *testing.T) {
+	rsl := `
+obj = parse_json('\{"a":1}')
+print(to_json('\{"a":1}'))
+print(to_json(obj))
+print(obj == '\{"a":1}', type_of(obj), len(obj), upper(obj))
+print("obj: {obj}")
+items string[] = parse_json('[\{"a":1}, "b"]')
+print(to_json(items), to_json(items[0] + ""))
+`
+	setupAndRunCode(t, rsl)
+	expected := `"{\"a\":1}"
+{"a":1}
+true string 7 {"A":1}
+obj: {"a":1}
+[{"a":1},"b"] "{\"a\":1}"
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
@@ -0,0 +1,126 @@
+package testing
+
+import "testing"
+
+func TestEnv(t *testing.T) {
+	testEnvVars.Set("WEATHER_API_KEY", "abc123")
+	rsl := `
+a = env("WEATHER_API_KEY")
+print(a)
+print(a + "!")
+`
+	setupAndRunCode(t, rsl)
+	expected := `abc123
+abc123!
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestEnvUnsetIsNull(t *testing.T) {
+	rsl := `
+a = env("WEATHER_API_KEY")
+print(a)
+print(a is null)
+`
+	setupAndRunCode(t, rsl)
+	expected := `null
+true
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestEnvDefault(t *testing.T) {
+	testEnvVars.Set("WEATHER_USERNAME", "alice")
+	rsl := `
+print(env("WEATHER_USERNAME", "not set"))
+print(env("WEATHER_API_KEY", "not set"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `alice
+not set
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestEnvSetButEmpty(t *testing.T) {
+	testEnvVars.Set("WEATHER_USERNAME", "")
+	rsl := `
+print("'" + env("WEATHER_USERNAME", "not set") + "'")
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "''\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestEnvRequired(t *testing.T) {
+	testEnvVars.Set("WEATHER_API_KEY", "abc123")
+	rsl := `
+print(env_required("WEATHER_API_KEY"))
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "abc123\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestEnvRequiredErrorsWhenUnset(t *testing.T) {
+	rsl := `
+print(env_required("WEATHER_API_KEY"))
+`
+	setupAndRunCode(t, rsl)
+	expected := "error: Environment variable 'WEATHER_API_KEY' is required, but is not set\n" +
+		" --> test:2:7\n" +
+		"2 | print(env_required(\"WEATHER_API_KEY\"))\n" +
+		"  |       ^^^^^^^^^^^^\n" +
+		"  = help: set it before running the script e.g. `export WEATHER_API_KEY=...`\n"
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestEnvRequiredSuggestsSimilarName(t *testing.T) {
+	testEnvVars.Set("WEATHER_API_KEY", "abc123")
+	rsl := `
+print(env_required("WEATHER_APIKEY"))
+`
+	setupAndRunCode(t, rsl)
+	expected := "error: Environment variable 'WEATHER_APIKEY' is required, but is not set\n" +
+		" --> test:2:7\n" +
+		"2 | print(env_required(\"WEATHER_APIKEY\"))\n" +
+		"  |       ^^^^^^^^^^^^\n" +
+		"  = help: did you mean `WEATHER_API_KEY`?\n"
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestSetEnv(t *testing.T) {
+	rsl := `
+set_env("REGION", "us-east-1")
+set_env("RETRIES", 3)
+print(env("REGION"), env("RETRIES"))
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "us-east-1 3\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSetEnvErrorsOnInvalidName(t *testing.T) {
+	rsl := `
+set_env("A=B", "c")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Invalid environment variable name: "A=B"
+ --> test:2:1
+2 | set_env("A=B", "c")
+  | ^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -0,0 +1,157 @@
+package testing
+
+import "testing"
+
+func TestAbsRoundFloorCeil(t *testing.T) {
+	rsl := `
+print(abs(-3), abs(2.5), abs(-0.5))
+print(round(2.5), round(-2.5), round(3.14159, 2), round(1234, -2))
+print(floor(2.7), floor(-2.2), ceil(2.2), ceil(-2.7), floor(5))
+`
+	setupAndRunCode(t, rsl)
+	expected := `3 2.5 0.5
+3 -3 3.14 1200
+2 -3 3 -2 5
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestSqrtPow(t *testing.T) {
+	rsl := `
+print(sqrt(16), sqrt(2))
+print(pow(2, 8), pow(2, 0.5), pow(10.0, -2))
+`
+	setupAndRunCode(t, rsl)
+	expected := `4 1.4142135623730951
+256 1.4142135623730951 0.01
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestIdiv(t *testing.T) {
+	rsl := `
+print(idiv(7, 2), idiv(-7, 2), idiv(6, 3))
+print(idiv(7.5, 2), idiv(7, 2.5), idiv(-7.5, 2.5), idiv(0.5, 1))
+`
+	setupAndRunCode(t, rsl)
+	expected := `3 -3 2
+3 2 -3 0
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestIdivByZero(t *testing.T) {
+	rsl := `
+print(idiv(7.5, 0.0))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Division by zero
+ --> test:2:7
+2 | print(idiv(7.5, 0.0))
+  |       ^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestIdivTooLarge(t *testing.T) {
+	rsl := `
+print(idiv(10.0 ** 30, 0.5))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: idiv(1000000000000000000000000000000, 0.5) is too large for an int
+ --> test:2:7
+2 | print(idiv(10.0 ** 30, 0.5))
+  |       ^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestSqrtNegative(t *testing.T) {
+	rsl := `
+print(sqrt(-4))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: sqrt() of a negative number: -4
+ --> test:2:7
+2 | print(sqrt(-4))
+  |       ^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestClamp(t *testing.T) {
+	rsl := `
+print(clamp(5, 0, 10), clamp(-5, 0, 10), clamp(15, 0, 10))
+print(clamp(15, 0, 9.5), clamp(0.5, 1, 2))
+`
+	setupAndRunCode(t, rsl)
+	expected := `5 0 10
+9.5 1
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestClampBoundsReversed(t *testing.T) {
+	rsl := `
+print(clamp(5, 10, 0))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: clamp() low bound 10 is greater than high bound 0
+ --> test:2:7
+2 | print(clamp(5, 10, 0))
+  |       ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestPercent(t *testing.T) {
+	rsl := `
+print(percent(1, 4), percent(1, 3), percent(1, 3, 1), percent(5, 2))
+`
+	setupAndRunCode(t, rsl)
+	expected := `25 33.33333333333333 33.3 250
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestPercentOfZero(t *testing.T) {
+	rsl := `
+print(percent(1, 0))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: percent() total must not be zero
+ --> test:2:7
+2 | print(percent(1, 0))
+  |       ^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestMathFunctionRejectsNonNumber(t *testing.T) {
+	rsl := `
+print(abs("3"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: abs() takes an int or float for 'value', got string
+ --> test:2:7
+2 | print(abs("3"))
+  |       ^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -2,8 +2,6 @@ package testing
 
 import "testing"
 
-// todo need to mock out huh so that we can write tests that actually filter down further when prompted
-
 func TestPickKvReturnsOnlyOption(t *testing.T) {
 	rsl := `
 keys = ["Chicken"]
@@ -39,7 +37,12 @@ values string[] = []
 pick_kv(keys, values)
 `
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L4/7 on 'pick_kv': pick_kv() requires keys and values to have at least one element\n")
+	expected := `error: pick_kv() requires keys and values to have at least one element
+ --> test:4:1
+4 | pick_kv(keys, values)
+  | ^^^^^^^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
 
@@ -50,7 +53,12 @@ values = ["Hamburger", "Chicken Burger"]
 pick_kv(keys, values)
 `
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L4/7 on 'pick_kv': pick_kv() requires keys and values to be the same length, got 1 keys and 2 values\n")
+	expected := `error: pick_kv() requires keys and values to be the same length, got 1 keys and 2 values
+ --> test:4:1
+4 | pick_kv(keys, values)
+  | ^^^^^^^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
 
@@ -67,3 +75,16 @@ print(pick_kv(keys, values, ["Be", "ef"]))
 	assertNoErrors(t)
 	resetTestState()
 }
+
+func TestPickKvPicksWhenSeveralMatch(t *testing.T) {
+	testPicker.Selections = []string{"Fish"}
+	rsl := `
+keys = ["Beef", "Chicken", "Fish"]
+values = [1, 2, 3]
+print(pick_kv(keys, values) * 10)
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "30\n")
+	assertNoErrors(t)
+	resetTestState()
+}
@@ -2,8 +2,6 @@ package testing
 
 import "testing"
 
-// todo need to mock out huh so that we can write tests that actually filter down further when prompted
-
 func TestPickNoFilterOneOption(t *testing.T) {
 	rsl := `
 opts = ["Hamburger"]
@@ -43,7 +41,12 @@ opts string[] = []
 pick(opts)
 `
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L3/4 on 'pick': Filtered 0 options to 0 with filters: []\n")
+	expected := `error: Filtered 0 options to 0 with filters: []
+ --> test:3:1
+3 | pick(opts)
+  | ^^^^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
 
@@ -53,7 +56,12 @@ opts = ["Hamburger", "Chicken Burger", "Sandwich", "Fish", "Chickwich"]
 pick(opts, "asdasdasd")
 `
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L3/4 on 'pick': Filtered 5 options to 0 with filters: [asdasdasd]\n")
+	expected := `error: Filtered 5 options to 0 with filters: [asdasdasd]
+ --> test:3:1
+3 | pick(opts, "asdasdasd")
+  | ^^^^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
 
@@ -68,3 +76,73 @@ print(pick(opts, filter))
 	assertNoErrors(t)
 	resetTestState()
 }
+
+func TestPickPicksWhenSeveralMatch(t *testing.T) {
+	testPicker.Selections = []string{"Chicken Burger"}
+	rsl := `
+opts = ["Hamburger", "Chicken Burger", "Sandwich", "Fish", "Chickwich"]
+print(pick(opts, "burger"))
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "Chicken Burger\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestPickSelectionsFromFlag(t *testing.T) {
+	rsl := `
+opts = ["Hamburger", "Chicken Burger", "Sandwich", "Fish", "Chickwich"]
+print(pick(opts, "wich"))
+print(pick(opts))
+`
+	setupAndRunCode(t, rsl, "--PICK", "chick", "--PICK", "Fish")
+	assertOnlyOutput(t, stdOutBuffer, "Chickwich\nFish\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestPickSelectionsFromEnv(t *testing.T) {
+	testEnvVars.Set("RAD_PICK", "sand,ham")
+	rsl := `
+opts = ["Hamburger", "Chicken Burger", "Sandwich", "Fish", "Chickwich"]
+print(pick(opts))
+print(pick(opts))
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "Sandwich\nHamburger\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestPickSelectionMatchingSeveralOptions(t *testing.T) {
+	rsl := `
+opts = ["Hamburger", "Chicken Burger", "Sandwich", "Fish", "Chickwich"]
+print(pick(opts))
+`
+	setupAndRunCode(t, rsl, "--PICK", "chick")
+	expected := `error: Error running pick: Selection "chick" matches 2 of the options: ["Hamburger" "Chicken Burger" "Sandwich" "Fish" "Chickwich"]
+ --> test:3:7
+3 | print(pick(opts))
+  |       ^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestPickRunsOutOfSelections(t *testing.T) {
+	testPicker.Selections = []string{"Fish"}
+	rsl := `
+opts = ["Hamburger", "Chicken Burger"]
+print(pick(["Fish", "Sandwich"]))
+print(pick(opts))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Error running pick: No selection left to pick from 2 options: ["Hamburger" "Chicken Burger"]
+ --> test:4:7
+4 | print(pick(opts))
+  |       ^^^^
+`
+	assertOutput(t, stdOutBuffer, "Fish\n")
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -122,3 +122,29 @@ func TestResourcePathIsRelativeToScript(t *testing.T) {
 	assertNoErrors(t)
 	resetTestState()
 }
+
+func TestPickFromResourceWithoutFilterMatchesOnlyEmptyKey(t *testing.T) {
+	rsl := `
+name, age = pick_from_resource("./resources/people.json")
+print(name, age)
+`
+	setupAndRunCode(t, rsl, "--PICK", "bob", "--NO-COLOR")
+	expected := `error: Filtered 2 options to 0 with filter: ""
+ --> test:2:13
+2 | name, age = pick_from_resource("./resources/people.json")
+  |             ^^^^^^^^^^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestPickFromResourceSharedKeyPicksByKey(t *testing.T) {
+	rsl := `
+url = pick_from_resource("./resources/environments.json", "dev")
+print(url)
+`
+	setupAndRunCode(t, rsl, "--PICK", "sandbox")
+	assertOnlyOutput(t, stdOutBuffer, "https://play.example.com\n")
+	assertNoErrors(t)
+	resetTestState()
+}
@@ -0,0 +1,118 @@
+package testing
+
+import (
+	"os"
+	"testing"
+)
+
+func TestInput(t *testing.T) {
+	rsl := `
+name = input("Name?")
+region = input("Region?", "us-east-1")
+print(name, region)
+`
+	setupAndRun(t, NewTestParams(rsl).StdinInput("alice\n\n"))
+	assertOnlyOutput(t, stdOutBuffer, "alice us-east-1\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestInputFromFile(t *testing.T) {
+	setupAndRun(t, NewTestParams("", "./rads/prompt.rad").StdinInput("bob"))
+	assertOnlyOutput(t, stdOutBuffer, "hi bob\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestConfirm(t *testing.T) {
+	rsl := `
+if confirm("Deploy?"):
+	print("deploying")
+if confirm("Again?"):
+	print("deploying again")
+else:
+	print("skipped")
+print(confirm("Default?"))
+`
+	setupAndRun(t, NewTestParams(rsl).StdinInput("y\nNo\n\n"))
+	assertOnlyOutput(t, stdOutBuffer, "deploying\nskipped\nfalse\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestConfirmInvalidAnswer(t *testing.T) {
+	setupAndRun(t, NewTestParams("", "./rads/confirm.rad").StdinInput("maybe\n"))
+	expected := `error: confirm() expected yes or no, got "maybe"
+ --> ./rads/confirm.rad:1:7
+1 | print(confirm("Deploy?"))
+  |       ^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestPasswordIsRedacted(t *testing.T) {
+	rsl := `
+token = password("Token?")
+print(token, len(token))
+`
+	setupAndRun(t, NewTestParams(rsl).StdinInput("hunter2\n"))
+	assertOnlyOutput(t, stdOutBuffer, "**** 7\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestMultipick(t *testing.T) {
+	rsl := `
+envs = ["dev", "staging", "prod"]
+chosen = multipick(envs, "Which environments?")
+print(chosen, len(chosen))
+print(multipick(envs))
+`
+	setupAndRun(t, NewTestParams(rsl).StdinInput("prod, dev\n\n"))
+	assertOnlyOutput(t, stdOutBuffer, "[dev, prod] 2\n[]\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestMultipickUnknownOption(t *testing.T) {
+	setupAndRun(t, NewTestParams("", "./rads/multipick.rad").StdinInput("prd\n"))
+	expected := `error: multipick() got "prd", which is not one of the options
+ --> ./rads/multipick.rad:1:7
+1 | print(multipick(["dev", "staging", "prod"]))
+  |       ^^^^^^^^^
+  = help: did you mean ` + "`prod`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestInputRunsOutOfAnswers(t *testing.T) {
+	setupAndRun(t, NewTestParams("", "./rads/prompt.rad"))
+	expected := `error: input() found no more answers in stdin
+ --> ./rads/prompt.rad:1:8
+1 | name = input("Name?")
+  |        ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestInputErrorsWithoutTerminal(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	testCmdInput.RIo.StdIn = devNull
+	defer func() { testCmdInput.RIo.StdIn = stdInBuffer }()
+
+	setupAndRunArgs(t, "./rads/prompt.rad")
+	expected := `error: input() needs an interactive terminal to prompt, but stdin is not a TTY
+ --> ./rads/prompt.rad:1:8
+1 | name = input("Name?")
+  |        ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -0,0 +1,176 @@
+package testing
+
+import "testing"
+
+func TestRangeForLoop(t *testing.T) {
+	rsl := `
+for i in range(3):
+	print(i)
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "0\n1\n2\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRangeStartEnd(t *testing.T) {
+	rsl := `
+for idx, i in range(5, 8):
+	print(idx, i)
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "0 5\n1 6\n2 7\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRangeStep(t *testing.T) {
+	rsl := `
+print(range(0, 10, 3))
+print(range(5, 0, -2))
+print(range(3, 3))
+print(range(5, 0))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[0, 3, 6, 9]
+[5, 3, 1]
+[]
+[]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRangeCanBeAssigned(t *testing.T) {
+	rsl := `
+a = range(3)
+a = a + [10]
+print(a)
+print(len(range(1, 100)))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[0, 1, 2, 10]
+99
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRangeIsLazyInForLoop(t *testing.T) {
+	rsl := `
+for i in range(1000000000000):
+	if i == 3:
+		break
+	print(i)
+`
+	setupAndRunCode(t, rsl)
+	expected := `0
+1
+2
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRangeIsLazyWhenIndexed(t *testing.T) {
+	rsl := `
+print(range(1000000000000)[5])
+print(range(10, -1000000000000, -3)[2])
+print(len(range(1000000000000)))
+print(len(range(-9223372036854775807, 9223372036854775807, 2)))
+`
+	setupAndRunCode(t, rsl)
+	expected := `5
+4
+1000000000000
+9223372036854775807
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRangeIndexOutOfBounds(t *testing.T) {
+	rsl := `
+print(range(1000000000000)[1000000000000])
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Array index out of bounds: 1000000000000 (length 1000000000000)
+ --> test:2:27
+2 | print(range(1000000000000)[1000000000000])
+  |                           ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestRangeLenTooLarge(t *testing.T) {
+	rsl := `
+print(len(range(-9223372036854775807, 9223372036854775807)))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: len() of a range() with 18446744073709551614 values is too large for an int
+ --> test:2:7
+2 | print(len(range(-9223372036854775807, 9223372036854775807)))
+  |       ^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestRangeTooLargeForArray(t *testing.T) {
+	rsl := `
+a = range(1000000000000)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: range() of 1000000000000 values is too large to make into an array (max 10000000)
+ --> test:2:5
+2 | a = range(1000000000000)
+  |     ^^^^^
+  = help: iterate over it directly with a for loop, which doesn't make an array
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestRangeListComprehension(t *testing.T) {
+	rsl := `
+print([i * 2 for i in range(4) if i != 1])
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "[0, 4, 6]\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRangeErrorsOnZeroStep(t *testing.T) {
+	rsl := `
+a = range(0, 5, 0)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: range() step cannot be zero
+ --> test:2:5
+2 | a = range(0, 5, 0)
+  |     ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestRangeErrorsOnNonInt(t *testing.T) {
+	rsl := `
+for i in range("a"):
+	print(i)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: range() takes an int for 'start', got string
+ --> test:2:10
+2 | for i in range("a"):
+  |          ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -0,0 +1,264 @@
+package testing
+
+import "testing"
+
+func TestSplit(t *testing.T) {
+	rsl := `
+print(split("a,b,,c", ","))
+print(split("a, b, c", ", ", 2))
+print(split("héllo", ""))
+print(split("日本語", "", 2))
+print(len(split("", ",")))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[a, b, , c]
+[a, b, c]
+[h, é, l, l, o]
+[日, 本語]
+1
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTrim(t *testing.T) {
+	rsl := `
+print("[" + trim("  hi there  ") + "]")
+print("[" + trim_left("  hi  ") + "]")
+print("[" + trim_right("  hi  ") + "]")
+print(trim("--=hi=--", "-="))
+print(trim_left("00042", "0"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[hi there]
+[hi  ]
+[  hi]
+hi
+42
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestPad(t *testing.T) {
+	rsl := `
+print("[" + pad_left("ab", 5) + "]")
+print("[" + pad_right("ab", 5) + "]")
+print(pad_left(42, 6, "0"))
+print(pad_right("é", 4, "-="))
+print(pad_left("toolong", 3))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[   ab]
+[ab   ]
+000042
+é-=-
+toolong
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestPadEmpty(t *testing.T) {
+	rsl := `
+print(pad_left("a", 3, ""))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: pad_left() needs a non-empty pad
+ --> test:2:7
+2 | print(pad_left("a", 3, ""))
+  |       ^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestSubstringAndIndexOf(t *testing.T) {
+	rsl := `
+s = "naïve café"
+print(substring(s, 0, 5))
+print(substring(s, 6))
+print(substring(s, -4, -1))
+print(substring(s, 3, 100))
+print(substring(s, 8, 2) == "")
+print(index_of(s, "café"), index_of(s, "x"), len(s))
+`
+	setupAndRunCode(t, rsl)
+	expected := `naïve
+café
+caf
+ve café
+true
+6 -1 10
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRepeatTitleTruncate(t *testing.T) {
+	rsl := `
+print(repeat("ab", 3), repeat("-", 0) == "")
+print(title("hello wide-world, it's an HTTP api"))
+print(truncate("Hello, world", 8))
+print(truncate("Hello, world", 8, "..."))
+print(truncate("Hello", 8))
+print(truncate("日本語のテキスト", 4))
+`
+	setupAndRunCode(t, rsl)
+	expected := `ababab true
+Hello Wide-World, It's An HTTP Api
+Hello, …
+Hello...
+Hello
+日本語…
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRepeatNegative(t *testing.T) {
+	rsl := `
+print(repeat("a", -1))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: repeat() count must not be negative, got -1
+ --> test:2:7
+2 | print(repeat("a", -1))
+  |       ^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestRegex(t *testing.T) {
+	rsl := `
+m = regex_match("version: 1.22.4", "([0-9]+)[.]([0-9]+)")
+print(m)
+if regex_match("abc", "[0-9]"):
+    print("matched")
+else:
+    print("no match")
+print(regex_find_all("a1 b22 c333", "[0-9]+"))
+print(regex_find_all("a=1 b=2", "([a-z])=([0-9])"))
+print(regex_find_all("abc", "[0-9]"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[1.22, 1, 22]
+no match
+[1, 22, 333]
+[[a, 1], [b, 2]]
+[]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestRegexInvalid(t *testing.T) {
+	rsl := `
+print(regex_match("abc", "(a"))
+`
+	setupAndRunCode(t, rsl)
+	expected := "error: Error compiling regex pattern: error parsing regexp: missing closing ): `(a`" + `
+ --> test:2:7
+2 | print(regex_match("abc", "(a"))
+  |       ^^^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestFormat(t *testing.T) {
+	rsl := `
+print(format("%-6s|%6.2f|%03d|%t", "ab", 3.14159, 7, true))
+print(format("%s %v %q", [1, 2], 2.5, "hi"))
+print(format("%.1f%% of %x", 12, 255))
+print(format("[%5s]", "日本"))
+print(format("no verbs"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `ab    |  3.14|007|true
+[1, 2] 2.5 "hi"
+12.0% of ff
+[   日本]
+no verbs
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestFormatWrongType(t *testing.T) {
+	rsl := `
+print(format("%d items", "three"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: format() expects an int, got string for "%d"
+ --> test:2:7
+2 | print(format("%d items", "three"))
+  |       ^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestFormatTooFewValues(t *testing.T) {
+	rsl := `
+print(format("%s and %s", "a"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: format() has more verbs than values: "%s and %s" needs at least 2, got 1
+ --> test:2:7
+2 | print(format("%s and %s", "a"))
+  |       ^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestFormatTooManyValues(t *testing.T) {
+	rsl := `
+print(format("%s", "a", "b"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: format() has more values than verbs: "%s" uses 1, got 2
+ --> test:2:7
+2 | print(format("%s", "a", "b"))
+  |       ^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestUrlEncoding(t *testing.T) {
+	rsl := `
+print(url_encode("a b&c=d/é"))
+print(url_decode("a+b%26c%3Dd%2F%C3%A9"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `a+b%26c%3Dd%2F%C3%A9
+a b&c=d/é
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestUrlDecodeInvalid(t *testing.T) {
+	rsl := `
+print(url_decode("100%"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Could not URL-decode "100%": invalid URL escape "%"
+ --> test:2:7
+2 | print(url_decode("100%"))
+  |       ^^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -0,0 +1,240 @@
+package testing
+
+import "testing"
+
+func TestNowAndToday(t *testing.T) {
+	rsl := `
+print(now(), today())
+print(now() - today())
+print(epoch_seconds(today()), epoch_millis("2019-12-13T00:00:01Z"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `2019-12-13T14:15:16Z 2019-12-13
+14h15m16.123123123s
+1576195200 1576195201000
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestParseTime(t *testing.T) {
+	rsl := `
+print(parse_time("2024-03-01T09:30:00+10:00"))
+print(parse_time("2024-03-01"))
+print(parse_time("-2h"))
+print(parse_time("01/03/2024 09:30", "%d/%m/%Y %H:%M"))
+print(parse_time("Mar 1, 2024", "Jan 2, 2006"))
+print(parse_time(1709285400))
+print(parse_duration("1d12h") / 2)
+`
+	setupAndRunCode(t, rsl)
+	expected := `2024-03-01T09:30:00+10:00
+2024-03-01T00:00:00Z
+2019-12-13T12:15:16Z
+2024-03-01T09:30:00Z
+2024-03-01T00:00:00Z
+2024-03-01T09:30:00Z
+18h
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestParseTimeStrftimeLiterals(t *testing.T) {
+	rsl := `
+print(parse_time("Report 1 of 2: 2024-03-01 (Mon Jan)", "Report 1 of 2: %Y-%m-%d (Mon Jan)"))
+print(parse_time("day 15 Mar 2024 at 01:05:09 PM, 100%", "day %d %b %Y at %I:%M:%S %p, 100%%"))
+print(parse_time("2024-03-01T09:05:07 +1000", "%FT%T %z"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `2024-03-01T00:00:00Z
+2024-03-15T13:05:09Z
+2024-03-01T09:05:07+10:00
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestParseTimeErrors(t *testing.T) {
+	rsl := `
+print(parse_time("1st March", "%d %B"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Could not parse time "1st March" with layout "%d %B"
+ --> test:2:7
+2 | print(parse_time("1st March", "%d %B"))
+  |       ^^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestParseTimeErrorsOnLayoutWithoutDirectives(t *testing.T) {
+	rsl := `
+print(parse_time("at %", "at %%"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Could not parse time "at %" with layout "at %%"
+ --> test:2:7
+2 | print(parse_time("at %", "at %%"))
+  |       ^^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestFormatTime(t *testing.T) {
+	rsl := `
+t = parse_time("2024-03-01T09:05:07.25Z")
+print(format_time(t, "%Y-%m-%d %H:%M:%S.%f"))
+print(format_time(t, "%a %d %b %y, %I:%M %p (day %j) 100%%"))
+print(format_time(t, "%A %B %e %T %Z %z"))
+print(format_time(t, "Monday, 02-Jan-06 15:04"))
+print(format_time(today(), "%F"))
+print(format_time("2024-03-01", "%s"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `2024-03-01 09:05:07.250000
+Fri 01 Mar 24, 09:05 AM (day 061) 100%
+Friday March  1 09:05:07 UTC +0000
+Friday, 01-Mar-24 09:05
+2019-12-13
+1709251200
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestFormatTimeUnsupportedDirective(t *testing.T) {
+	rsl := `
+print(format_time(now(), "%Q"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Unsupported directive: %Q
+ --> test:2:7
+2 | print(format_time(now(), "%Q"))
+  |       ^^^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestToTimezone(t *testing.T) {
+	rsl := `
+print(to_timezone(now(), "Australia/Sydney"))
+print(to_timezone("2024-07-01T12:00:00Z", "America/New_York"))
+print(format_time(to_timezone(now(), "Asia/Kolkata"), "%H:%M %Z"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `2019-12-14T01:15:16+11:00
+2024-07-01T08:00:00-04:00
+19:45 IST
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestToTimezoneUnknown(t *testing.T) {
+	rsl := `
+print(to_timezone(now(), "Mars/Olympus_Mons"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Unknown time zone: "Mars/Olympus_Mons"
+ --> test:2:7
+2 | print(to_timezone(now(), "Mars/Olympus_Mons"))
+  |       ^^^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTimeAgo(t *testing.T) {
+	rsl := `
+print(time_ago(now()))
+print(time_ago(now() - parse_duration("30s")))
+print(time_ago(now() - parse_duration("1m")))
+print(time_ago("2019-12-13T11:00:00Z"))
+print(time_ago(today() - parse_duration("1d")))
+print(time_ago(today() - parse_duration("15d")))
+print(time_ago("2019-10-01"))
+print(time_ago("2017-01-01"))
+print(time_ago(add_days(today(), 3)))
+`
+	setupAndRunCode(t, rsl)
+	expected := `just now
+just now
+1 minute ago
+3 hours ago
+1 day ago
+2 weeks ago
+2 months ago
+2 years ago
+in 2 days
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	rsl := `
+print(humanize_duration(parse_duration("90s")))
+print(humanize_duration(parse_duration("36h")))
+print(humanize_duration(parse_duration("-2w")))
+print(humanize_duration(parse_duration("500ms")))
+`
+	setupAndRunCode(t, rsl)
+	expected := `1 minute
+1 day
+-2 weeks
+0 seconds
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestCalendarArithmetic(t *testing.T) {
+	rsl := `
+print(add_days(today(), -13))
+print(add_months("2024-01-31", 1))
+print(add_months(parse_time("2024-03-31T10:00:00Z"), -1))
+print(add_months("2024-03-31", -13))
+print(add_years(today(), 5))
+print(add_years("2024-02-29", 1))
+print(days_between("2024-02-01", "2024-03-01"))
+print(days_between(now(), "2019-12-01T23:59:59Z"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `2019-11-30
+2024-02-29T00:00:00Z
+2024-02-29T10:00:00Z
+2023-02-28T00:00:00Z
+2024-12-13
+2025-02-28T00:00:00Z
+29
+-12
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTimeFunctionRejectsNonTime(t *testing.T) {
+	rsl := `
+print(add_days(5, 1))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: add_days() takes a date, datetime, or string for 'time', got int
+ --> test:2:7
+2 | print(add_days(5, 1))
+  |       ^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
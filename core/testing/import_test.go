@@ -0,0 +1,196 @@
+package testing
+
+import "testing"
+
+func TestImportNamespacesVariables(t *testing.T) {
+	rsl := `
+import "rads/modules/common.rad"
+print(common.base_url)
+print(common.retries + 1)
+print(common.names[1])
+print(common.greeting)
+`
+	setupAndRunCode(t, rsl)
+	expected := `https://api.example.com
+4
+bob
+hello from https://api.example.com
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestImportWithAlias(t *testing.T) {
+	rsl := `
+import "rads/modules/common.rad" as api
+url = api.base_url + "/repos"
+print(url)
+`
+	setupAndRunCode(t, rsl)
+	expected := `https://api.example.com/repos
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestImportResolvesNestedImportsRelativeToModule(t *testing.T) {
+	rsl := `
+import "rads/modules/nested.rad"
+print(nested.endpoint)
+`
+	setupAndRunCode(t, rsl)
+	expected := `https://api.example.com/users
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestImportUsesSearchPath(t *testing.T) {
+	testEnvVars.Set("RAD_PATH", "/does/not/exist:rads/modules")
+	rsl := `
+import "common.rad"
+print(common.retries)
+`
+	setupAndRunCode(t, rsl)
+	expected := `3
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestImportDoesNotLeakModuleVarsIntoScope(t *testing.T) {
+	rsl := `
+import "rads/modules/common.rad"
+print(retries)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Undefined variable referenced: retries
+ --> test:3:7
+3 | print(retries)
+  |       ^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestImportErrorsOnUnknownModuleVariable(t *testing.T) {
+	rsl := `
+import "rads/modules/common.rad"
+print(common.nope)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Module 'common' has no variable 'nope'
+ --> test:3:14
+3 | print(common.nope)
+  |              ^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestImportErrorsOnMissingModule(t *testing.T) {
+	rsl := `
+import "rads/modules/missing.rad"
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Could not find module "rads/modules/missing.rad" relative to "." or in $RAD_PATH
+ --> test:2:1
+2 | import "rads/modules/missing.rad"
+  | ^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestImportDetectsCycles(t *testing.T) {
+	rsl := `
+import "rads/modules/cycle_a.rad"
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Import cycle detected: cycle_a.rad -> cycle_b.rad -> cycle_a.rad
+ --> cycle_b.rad:1:1
+1 | import "cycle_a.rad"
+  | ^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestImportAttributesErrorsToModuleFile(t *testing.T) {
+	rsl := `
+import "rads/modules/broken.rad"
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Invalid binary operand types: int, bool
+ --> rads/modules/broken.rad:2:7
+2 | b = a + true
+  |       ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestImportRejectsModulesWithArgs(t *testing.T) {
+	rsl := `
+import "rads/modules/with_args.rad"
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Imported modules cannot declare an args block
+ --> rads/modules/with_args.rad:1:1
+1 | args:
+  | ^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestImportChecksModuleBeforeRunning(t *testing.T) {
+	rsl := `
+print("before")
+import "rads/modules/broken.rad"
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Invalid binary operand types: int, bool
+ --> rads/modules/broken.rad:2:7
+2 | b = a + true
+  |       ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestImportChecksModuleVariablesBeforeRunning(t *testing.T) {
+	rsl := `
+print("before")
+import "rads/modules/common.rad"
+print(pad_left("a", common.base_url))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: pad_left() takes an int for 'width', got string
+ --> test:4:7
+4 | print(pad_left("a", common.base_url))
+  |       ^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestCheckReportsUnknownModuleVariable(t *testing.T) {
+	rsl := `
+import "rads/modules/common.rad"
+print(common.nope)
+`
+	setupAndRunCode(t, rsl, "check")
+	expected := `error: Module 'common' has no variable 'nope'
+ --> test:3:14
+3 | print(common.nope)
+  |              ^^^^
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertExitCode(t, 1)
+	resetTestState()
+}
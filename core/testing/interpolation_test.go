@@ -0,0 +1,130 @@
+package testing
+
+import "testing"
+
+func TestInterpolationExpressions(t *testing.T) {
+	rsl := `
+names = ["alice", "bob"]
+a = 3
+b = 4
+print("{len(names)} {a + b} {names[1]} {upper(names[0])} {join(names, ', ')}")
+print("\{a} {a}} {names}")
+`
+	setupAndRunCode(t, rsl)
+	expected := `2 7 bob ALICE alice, bob
+{a} 3} [alice, bob]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestInterpolationFormats(t *testing.T) {
+	rsl := `
+price = 1234.5
+name = "bob"
+count = 1234567
+print("[{price:.2f}] [{price:,.1f}] [{count:,}] [{count:_}] [{0.256:.1%}]")
+print("[{name:<6}] [{name:>6}] [{name:*^7}] [{name:.2}] [{count:>10}] [{count:<10}]")
+print("[{7:03}] [{-7:+05}] [{7:+}] [{255:x}] [{255:#>4X}] [{5:b}] [{8:o}] [{count:.2e}]")
+`
+	setupAndRunCode(t, rsl)
+	expected := `[1234.50] [1,234.5] [1,234,567] [1_234_567] [25.6%]
+[bob   ] [   bob] [**bob**] [bo] [   1234567] [1234567   ]
+[007] [-0007] [+7] [ff] [##FF] [101] [10] [1.23e+06]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestInterpolationParseErrorPointsIntoString(t *testing.T) {
+	rsl := `
+a = 1
+print("a is {a +} here")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Expected expression
+ --> test:3:17
+3 | print("a is {a +} here")
+  |                 ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestInterpolationUnterminated(t *testing.T) {
+	rsl := `
+a = 1
+print("a is {a")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Unterminated interpolation, expected '}'
+ --> test:3:13
+3 | print("a is {a")
+  |             ^^
+  = help: to write a literal brace, escape it e.g. ` + "`\\{`" + `
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestInterpolationInvalidFormat(t *testing.T) {
+	rsl := `
+price = 1.5
+print("{price:.2q}")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Invalid format ".2q": unexpected "q"
+ --> test:3:15
+3 | print("{price:.2q}")
+  |               ^^^
+  = help: formats are [[fill]align][sign][0][width][,][.precision][type] e.g. ` + "`{price:>10,.2f}`" +
+		`, where type is one of s, d, f, e, %, x, X, o, b
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestInterpolationFormatMismatchIsTypeChecked(t *testing.T) {
+	rsl := `
+name = "bob"
+print("{name:,}")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Format "," needs an int or float, got a string
+ --> test:3:8
+3 | print("{name:,}")
+  |        ^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestInterpolationFormatMismatchAtRuntime(t *testing.T) {
+	rsl := `
+value = parse_json('1.5')
+print("{value:x}")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Format "x" needs an int, got a float
+ --> test:3:8
+3 | print("{value:x}")
+  |        ^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestInterpolationBracesInArgRegexUnaffected(t *testing.T) {
+	rsl := `
+args:
+	code string
+	code regex "^[a-z]{3}$"
+print("{code}")
+`
+	setupAndRunCode(t, rsl, "abc")
+	assertOnlyOutput(t, stdOutBuffer, "abc\n")
+	assertNoErrors(t)
+	resetTestState()
+}
@@ -268,3 +268,23 @@ print(node)
 	assertNoErrors(t)
 	resetTestState()
 }
+
+func TestCapturedObjectsWrittenAsJsonObjects(t *testing.T) {
+	rsl := `
+url = "https://google.com"
+
+Results = json.results
+
+request url:
+    fields Results
+print(to_json(Results))
+`
+
+	setupAndRunCode(t, rsl, "--MOCK-RESPONSE", ".*:./responses/unique_keys.json", "--NO-COLOR")
+	expected := `{"Alice":{"age":30,"hometown":"New York"},"Bob":{"age":40,"hometown":"Los Angeles"}}
+`
+	assertOutput(t, stdOutBuffer, expected)
+	assertOutput(t, stdErrBuffer, "Mocking response for url (matched \".*\"): https://google.com\n")
+	assertNoErrors(t)
+	resetTestState()
+}
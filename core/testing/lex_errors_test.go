@@ -0,0 +1,53 @@
+package testing
+
+import "testing"
+
+func TestLexErrorExits(t *testing.T) {
+	rsl := `
+a = 1 $
+print("after")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Unexpected character
+ --> test:2:7
+2 | a = 1 $
+  |       ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestLexErrorPointsAtUnterminatedMultilineString(t *testing.T) {
+	rsl := `
+a = """
+  first
+  second
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Unterminated string
+ --> test:2:5
+2 | a = """
+  |     ^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestLexTracksLinesAfterFileHeader(t *testing.T) {
+	rsl := `
+---
+Does a thing.
+
+More about it.
+---
+print(b)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Undefined variable referenced: b
+ --> test:7:7
+7 | print(b)
+  |       ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
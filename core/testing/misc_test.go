@@ -4,6 +4,11 @@ import "testing"
 
 func TestSyntaxError(t *testing.T) {
 	setupAndRunArgs(t, "./rads/invalid_syntax.rad")
-	assertError(t, 1, "RslError at L1/1 on '1': Expected Identifier\n")
+	expected := `error: Expected Identifier
+ --> ./rads/invalid_syntax.rad:1:1
+1 | 1 2 3
+  | ^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
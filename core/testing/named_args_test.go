@@ -0,0 +1,155 @@
+package testing
+
+import "testing"
+
+func TestNamedArgs(t *testing.T) {
+	rsl := `
+print(join(["a", "b"], ",", suffix="]"))
+print(join(values=["a", "b"], separator="-", prefix="["))
+print(range(5, step=2))
+print(range(start=1, end=4))
+print(env("RAD_TEST_MISSING", default="fallback"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `a,b]
+[a-b
+[0, 2, 4]
+[1, 2, 3]
+fallback
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestNamedArgSkippingOptionalParams(t *testing.T) {
+	testPicker.Selections = []string{"Fish"}
+	rsl := `
+print(pick(["Hamburger", "Fish"], prompt="Lunch?"))
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "Fish\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestNamedArgUnknown(t *testing.T) {
+	rsl := `
+print(join(["a"], ",", sufix="]"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: join() has no parameter 'sufix'
+ --> test:2:24
+2 | print(join(["a"], ",", sufix="]"))
+  |                        ^^^^^
+  = help: did you mean ` + "`suffix`" + `?
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestNamedArgGivenTwice(t *testing.T) {
+	rsl := `
+print(join(["a"], ",", separator="-"))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: join() got multiple values for 'separator'
+ --> test:2:24
+2 | print(join(["a"], ",", separator="-"))
+  |                        ^^^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestNamedArgMissingRequired(t *testing.T) {
+	rsl := `
+print(join(["a"], prefix="["))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: join() is missing required argument 'separator'
+ --> test:2:7
+2 | print(join(["a"], prefix="["))
+  |       ^^^^
+  = help: usage: ` + "`join(values, separator, prefix?, suffix?)`" + `
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestNamedArgWrongType(t *testing.T) {
+	rsl := `
+print(pick(["a", "b"], prompt=1))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: pick() takes a string for 'prompt', got int
+ --> test:2:24
+2 | print(pick(["a", "b"], prompt=1))
+  |                        ^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestNamedArgForVariadicParam(t *testing.T) {
+	rsl := `
+print(values="a")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: print() takes 'values' only as positional arguments
+ --> test:2:7
+2 | print(values="a")
+  |       ^^^^^^
+  = help: usage: ` + "`print(values...)`" + `
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestNamedArgUnknownForVariadicFunction(t *testing.T) {
+	rsl := `
+print("a", end="")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: print() has no parameter 'end'
+ --> test:2:12
+2 | print("a", end="")
+  |            ^^^
+  = help: usage: ` + "`print(values...)`" + `
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestNamedArgsBeforeVariadicParam(t *testing.T) {
+	rsl := `
+values = ["apple", "banana", "avocado"]
+print(filter(values, function="starts_with", "a"))
+print(filter(values=values, function="ends_with", "a"))
+print(map(values, function="upper"))
+print(map(function="pad_left", values=values, 8, "."))
+`
+	setupAndRunCode(t, rsl)
+	expected := `[apple, avocado]
+[banana]
+[APPLE, BANANA, AVOCADO]
+[...apple, ..banana, .avocado]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestPositionalArgAfterNamed(t *testing.T) {
+	rsl := `
+print(join(["a"], separator=",", "["))
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Positional arguments must come before named arguments
+ --> test:2:34
+2 | print(join(["a"], separator=",", "["))
+  |                                  ^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
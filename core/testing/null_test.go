@@ -0,0 +1,194 @@
+package testing
+
+import "testing"
+
+func TestNullLiteralPrints(t *testing.T) {
+	rsl := `
+a = null
+print(a)
+print("a is {a}")
+print([1, null, "x"])
+`
+	setupAndRunCode(t, rsl)
+	expected := `null
+a is null
+[1, null, x]
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestNullIsCheck(t *testing.T) {
+	rsl := `
+a = null
+b = 2
+print(a is null)
+print(a is not null)
+print(b is null)
+print(b is not null)
+`
+	setupAndRunCode(t, rsl)
+	expected := `true
+false
+false
+true
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestNullEquality(t *testing.T) {
+	rsl := `
+a = null
+print(a == null)
+print(a != null)
+print(1 == null)
+print("null" == null)
+`
+	setupAndRunCode(t, rsl)
+	expected := `true
+false
+false
+false
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestNullCoalescing(t *testing.T) {
+	rsl := `
+a = null
+b = "set"
+print(a ?? "default")
+print(b ?? "default")
+print(a ?? null ?? 3)
+print(false ?? true)
+`
+	setupAndRunCode(t, rsl)
+	expected := `default
+set
+3
+false
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestNullCoalescingShortCircuits(t *testing.T) {
+	rsl := `
+a = [1]
+print(a[0] ?? a[5])
+`
+	setupAndRunCode(t, rsl)
+	expected := `1
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestNullIsFalsy(t *testing.T) {
+	rsl := `
+a = null
+if a:
+    print("truthy")
+else:
+    print("falsy")
+if a is null or a > 2:
+    print("short-circuits")
+`
+	setupAndRunCode(t, rsl)
+	expected := `falsy
+short-circuits
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestNullErrorsInArithmetic(t *testing.T) {
+	rsl := `
+a = null
+b = a + 1
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Cannot apply '+' to null
+ --> test:3:7
+3 | b = a + 1
+  |       ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestNullCanBeAssignedToTypedVar(t *testing.T) {
+	rsl := `
+a string = null
+print(a ?? "empty")
+a = "full"
+print(a ?? "empty")
+`
+	setupAndRunCode(t, rsl)
+	expected := `empty
+full
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestOptionalArgWithoutDefaultIsNull(t *testing.T) {
+	rsl := `
+args:
+	city string?
+	country string?
+if city:
+	print("city: " + city)
+else:
+	print("no city")
+print(city is null)
+print(country ?? "nowhere")
+`
+	setupAndRunCode(t, rsl, "--country", "NZ")
+	expected := `no city
+true
+NZ
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestOptionalArgWithValueIsNotNull(t *testing.T) {
+	rsl := `
+args:
+	city string?
+if city:
+	print("city: " + city)
+`
+	setupAndRunCode(t, rsl, "Auckland")
+	expected := `city: Auckland
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestJsonNullBecomesNull(t *testing.T) {
+	rsl := `
+a = ["x", null]
+print(a[1] is null)
+print(a[0] is null)
+`
+	setupAndRunCode(t, rsl)
+	expected := `true
+false
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
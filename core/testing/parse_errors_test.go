@@ -0,0 +1,137 @@
+package testing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParserReportsMultipleErrors(t *testing.T) {
+	rsl := `
+print("should not print")
+a = = 1
+b = 2
+c = (3
+print(b)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Expected expression
+ --> test:3:5
+3 | a = = 1
+  |     ^
+
+error: Expected ')' after expression
+ --> test:5:7
+5 | c = (3
+  |       ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestParserRecoversInsideBlocks(t *testing.T) {
+	rsl := `
+for x in [1, 2]:
+    y = = x
+    print(x)
+    z = [
+print("after")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Expected expression
+ --> test:3:9
+3 |     y = = x
+  |         ^
+
+error: Expected expression
+ --> test:5:10
+5 |     z = [
+  |          ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestParserSkipsBlocksOfInvalidStatements(t *testing.T) {
+	rsl := `
+if x = 2:
+    a = = 1
+else:
+    b = = 2
+c = = 3
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Expected ':' after if condition
+ --> test:2:6
+2 | if x = 2:
+  |      ^
+
+error: Expected expression
+ --> test:6:5
+6 | c = = 3
+  |     ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestParserStopsAfterTooManyErrors(t *testing.T) {
+	rsl := strings.Repeat("a = = 1\n", 15)
+	setupAndRunCode(t, rsl)
+	output := stdErrBuffer.String()
+	stdErrBuffer.Reset()
+	if strings.Count(output, "error: Expected expression") != 10 {
+		t.Errorf("Expected 10 errors to be reported, got:\n%s", output)
+	}
+	if !strings.HasSuffix(output, "error: Stopped after 10 errors\n") {
+		t.Errorf("Expected output to end with the error limit, got:\n%s", output)
+	}
+	assertExitCode(t, 1)
+	resetTestState()
+}
+
+func TestParserRecoversInsideRadBlocks(t *testing.T) {
+	rsl := `
+url = "https://example.com"
+Name = json[].name
+rad url:
+    fields Name Name
+print("x")
+print(1 +)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Expected ',' between identifiers
+ --> test:5:17
+5 |     fields Name Name
+  |                 ^^^^
+
+error: Expected expression
+ --> test:7:10
+7 | print(1 +)
+  |          ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestParserRecoversInsideSwitchBlocks(t *testing.T) {
+	rsl := `
+name = "alice"
+result = switch name:
+    case "alice": = 1
+    case "bob": 2
+print(1 +)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Expected expression
+ --> test:4:19
+4 |     case "alice": = 1
+  |                   ^
+
+error: Expected expression
+ --> test:6:10
+6 | print(1 +)
+  |          ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
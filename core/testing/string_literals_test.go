@@ -0,0 +1,96 @@
+package testing
+
+import "testing"
+
+func TestMultilineStringIsDedented(t *testing.T) {
+	rsl := `
+name = "alice"
+body = """
+    \{
+        "name": "{name}",
+        "tags": ["a", "b"]
+    }
+    """
+print(body)
+print("done")
+`
+	setupAndRunCode(t, rsl)
+	expected := `{
+    "name": "alice",
+    "tags": ["a", "b"]
+}
+done
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestMultilineStringKeepsBlankLinesAndExtraIndent(t *testing.T) {
+	rsl := `
+text = '''
+	first
+
+	  second
+	'''
+print(text)
+print("""on one line, with "quotes" in it""")
+`
+	setupAndRunCode(t, rsl)
+	expected := `first
+
+  second
+on one line, with "quotes" in it
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestMultilineStringErrorPointsToLine(t *testing.T) {
+	rsl := `
+text = """
+    first
+    second {missing}
+    """
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Undefined variable referenced: missing
+ --> test:4:13
+4 |     second {missing}
+  |             ^^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestRawStrings(t *testing.T) {
+	rsl := `
+name = "alice"
+print(r"\d{3} {name}", r'C:\new\table')
+print(replace("a123b45", r"\d+", "#"))
+print(r"""
+    raw {name} \n
+    """)
+`
+	setupAndRunCode(t, rsl)
+	expected := `\d{3} {name} C:\new\table
+a#b#
+raw {name} \n
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestIdentifiersStartingWithR(t *testing.T) {
+	rsl := `
+r = 1
+rows = r + 1
+print(r, rows)
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "1 2\n")
+	assertNoErrors(t)
+	resetTestState()
+}
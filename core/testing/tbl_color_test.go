@@ -138,6 +138,33 @@ rad url:
 		color color "o[a-z]"
 `
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L8/8 on 'color': Invalid color value \"licorice\". Allowed: [black red green yellow blue magenta cyan white]\n")
+	expected := `error: Invalid color value "licorice". Allowed: [black red green yellow blue magenta cyan white]
+ --> test:8:3
+8 | 		color color "o[a-z]"
+  | 		^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestRadColor_RawStringRegex(t *testing.T) {
+	rsl := `
+url = "https://google.com"
+name = json[].name
+city = json[].city
+rad url:
+    fields name, city
+    city:
+       color "red" r"^\w{5}$"
+`
+	setupAndRunCode(t, rsl, "--MOCK-RESPONSE", ".*:./responses/people.json")
+	expected := yellow("name   ") + "  " + yellow("city       ") + " \n"
+	expected += "Charlie  " + red("Paris") + "        \n"
+	expected += "Bob      London       \n"
+	expected += "Alice    New York     \n"
+	expected += "Bob      Los Angeles  \n"
+
+	assertOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
 	resetTestState()
 }
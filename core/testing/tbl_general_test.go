@@ -57,7 +57,12 @@ request:
     fields Name, Age
 `
 	setupAndRunCode(t, rsl)
-	assertError(t, 1, "RslError at L5/8 on ':': Expecting url or other source for request statement\n")
+	expected := `error: Expecting url or other source for request statement
+ --> test:5:8
+5 | request:
+  |        ^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
 
@@ -88,7 +93,12 @@ display url:
     fields Name, Age
 `
 	setupAndRunCode(t, rsl, "--NO-COLOR")
-	assertError(t, 1, "RslError at L5/11 on 'url': Expecting ':' to immediately follow \"display\", preceding indented block\n")
+	expected := `error: Expecting ':' to immediately follow "display", preceding indented block
+ --> test:5:9
+5 | display url:
+  |         ^^^
+`
+	assertError(t, 1, expected)
 	resetTestState()
 }
 
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
 	"rad/core"
 	"testing"
 	"time"
@@ -17,6 +18,8 @@ var (
 	stdOutBuffer = new(bytes.Buffer)
 	stdErrBuffer = new(bytes.Buffer)
 	errorOrExit  = ErrorOrExit{}
+	testEnvVars  = core.NewFakeEnvVars()
+	testPicker   = core.NewScriptedPicker()
 	// dont need reset
 	testCmdInput = newTestCmdInput()
 )
@@ -37,14 +40,16 @@ func newTestCmdInput() core.CmdInput {
 			StdOut: stdOutBuffer,
 			StdErr: stdErrBuffer,
 		},
-		RExit:  &testExitFunc,
-		RClock: core.NewFixedClock(2019, 12, 13, 14, 15, 16, 123123123, time.UTC),
+		RExit:    &testExitFunc,
+		RClock:   core.NewFixedClock(2019, 12, 13, 14, 15, 16, 123123123, time.UTC),
+		REnvVars: testEnvVars,
+		RPicker:  testPicker,
 	}
 }
 
 type TestParams struct {
 	rsl        string
-	stdinInput string // todo not implemented
+	stdinInput string
 	args       []string
 }
 
@@ -73,10 +78,18 @@ func setupAndRun(t *testing.T, tp *TestParams) {
 	t.Helper()
 
 	args := tp.args
-	if tp.rsl != "" {
+	if tp.rsl != "" && tp.stdinInput != "" {
+		// stdin is taken by the input, so the script is run from a file instead
+		scriptPath := filepath.Join(t.TempDir(), "test")
+		if err := os.WriteFile(scriptPath, []byte(tp.rsl), 0644); err != nil {
+			t.Fatalf("Could not write script: %v", err)
+		}
+		args = append([]string{scriptPath}, tp.args...)
+	} else if tp.rsl != "" {
 		stdInBuffer.WriteString(tp.rsl)
 		args = append([]string{"--STDIN", "test"}, tp.args...)
 	}
+	stdInBuffer.WriteString(tp.stdinInput)
 	rootCmd := setupCmd(t, args...)
 	defer func() {
 		if r := recover(); r != nil {
@@ -106,6 +119,8 @@ func resetTestState() {
 	stdOutBuffer.Reset()
 	stdErrBuffer.Reset()
 	errorOrExit = ErrorOrExit{}
+	testEnvVars.Vars = make(map[string]string)
+	testPicker.Selections = nil
 	core.ResetGlobals()
 }
 
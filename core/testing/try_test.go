@@ -0,0 +1,210 @@
+package testing
+
+import "testing"
+
+func TestTryCatchRecoversFromError(t *testing.T) {
+	rsl := `
+a = [1, 2, 3]
+try:
+    print("before")
+    print(a[5])
+    print("after")
+catch err:
+    print("caught: " + err)
+print("done")
+`
+	setupAndRunCode(t, rsl)
+	expected := `before
+caught: Array index out of bounds: 5 (length 3)
+done
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTryCatchSkipsCatchWithoutError(t *testing.T) {
+	rsl := `
+try:
+    print("fine")
+catch:
+    print("unreachable")
+`
+	setupAndRunCode(t, rsl)
+	expected := `fine
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTryCatchBindsErrorLocation(t *testing.T) {
+	rsl := `
+try:
+    b = 1 + true
+catch err, loc:
+    print(loc)
+    print(err)
+`
+	setupAndRunCode(t, rsl)
+	expected := `L3/11
+Invalid binary operand types: int64, bool
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTryCatchErrorVarIsScopedToCatch(t *testing.T) {
+	rsl := `
+try:
+    b = 1 + true
+catch err:
+    a = 1
+print(err)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Undefined variable referenced: err
+ --> test:6:7
+6 | print(err)
+  |       ^^^
+  = note: 'err' is defined in an earlier block, and isn't visible outside of it
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTryCatchCanBeNested(t *testing.T) {
+	rsl := `
+try:
+    try:
+        b = 1 + true
+    catch:
+        print("inner")
+    c = [1][2]
+catch:
+    print("outer")
+`
+	setupAndRunCode(t, rsl)
+	expected := `inner
+outer
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTryCatchErrorInCatchStillExits(t *testing.T) {
+	rsl := `
+items = [1, 2]
+idx = 5
+try:
+    a = items[idx]
+catch:
+    print("caught")
+    b = items[idx + 1]
+print("unreachable")
+`
+	setupAndRunCode(t, rsl)
+	assertOutput(t, stdOutBuffer, "caught\n")
+	expected := `error: Array index out of bounds: 6 (length 2)
+ --> test:8:14
+8 |     b = items[idx + 1]
+  |              ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTryCatchBreakInsideLoop(t *testing.T) {
+	rsl := `
+for x in [1, 2, 3]:
+    try:
+        if x == 2:
+            break
+        print(x)
+    catch:
+        print("unreachable")
+print("done")
+`
+	setupAndRunCode(t, rsl)
+	expected := `1
+done
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestFallibleReturnsValueWithoutError(t *testing.T) {
+	rsl := `
+a = [1, 2, 3]
+b = try a[1] or 10
+print(b)
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "2\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestFallibleReturnsFallbackOnError(t *testing.T) {
+	rsl := `
+a = [1, 2, 3]
+b = try a[5] or 10
+print(b)
+print(try 1 + true or "fallback")
+`
+	setupAndRunCode(t, rsl)
+	expected := `10
+fallback
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestFallibleFallbackErrorsStillExit(t *testing.T) {
+	rsl := `
+a = [1, 2, 3]
+b = try a[5] or a[6]
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Array index out of bounds: 6 (length 3)
+ --> test:3:18
+3 | b = try a[5] or a[6]
+  |                  ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestFallibleRequiresOr(t *testing.T) {
+	rsl := `
+a = try 1 + 2
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Expected 'or' followed by a fallback value after 'try' expression
+ --> test:2:14
+2 | a = try 1 + 2
+  |              ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTryRequiresCatch(t *testing.T) {
+	rsl := `
+try:
+    print("hi")
+print("bye")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Expected 'catch' block after 'try' block
+ --> test:4:1
+4 | print("bye")
+  | ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -0,0 +1,207 @@
+package testing
+
+import "testing"
+
+func TestTypeCheckReportsAllErrorsBeforeRunning(t *testing.T) {
+	rsl := `
+print("should not print")
+a = 1 + "x"
+b = upper("a", "b")
+print(c)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Invalid binary operand types: int, string
+ --> test:3:7
+3 | a = 1 + "x"
+  |       ^
+
+error: upper() takes exactly 1 argument, got 2
+ --> test:4:5
+4 | b = upper("a", "b")
+  |     ^^^^^
+  = help: usage: ` + "`upper(value)`" + `
+
+error: Undefined variable referenced: c
+ --> test:5:7
+5 | print(c)
+  |       ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTypeCheckUndefinedVariableInString(t *testing.T) {
+	rsl := `
+name = "alice"
+print("hi {name}, from {sender}")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Undefined variable referenced: sender
+ --> test:3:25
+3 | print("hi {name}, from {sender}")
+  |                         ^^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTypeCheckUnknownFunction(t *testing.T) {
+	rsl := `
+a = nope(1)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Unknown function: nope
+ --> test:2:5
+2 | a = nope(1)
+  |     ^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTypeCheckFunctionArgCounts(t *testing.T) {
+	rsl := `
+a = join(["a"])
+b = range()
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: join() takes 2 to 4 arguments, got 1
+ --> test:2:5
+2 | a = join(["a"])
+  |     ^^^^
+  = help: usage: ` + "`join(values, separator, prefix?, suffix?)`" + `
+
+error: range() takes 1 to 3 arguments, got 0
+ --> test:3:5
+3 | b = range()
+  |     ^^^^^
+  = help: usage: ` + "`range(start, end?, step?)`" + `
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTypeCheckVoidFunctionAsValue(t *testing.T) {
+	rsl := `
+a = print("x")
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: print() does not return a value
+ --> test:2:5
+2 | a = print("x")
+  |     ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTypeCheckTypedAssignmentMismatch(t *testing.T) {
+	rsl := `
+a int = "one"
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Type mismatch, expected int, got string
+ --> test:2:1
+2 | a int = "one"
+  | ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTypeCheckInfersThroughVariables(t *testing.T) {
+	rsl := `
+a = len("abc")
+b = a * 2
+c = b > true
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Invalid binary operand types: int, bool
+ --> test:4:7
+4 | c = b > true
+  |       ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTypeCheckIgnoresErrorsInTry(t *testing.T) {
+	rsl := `
+try:
+    a = 1 + true
+catch:
+    print("caught")
+b = try 2 + "x" or 3
+print(b)
+`
+	setupAndRunCode(t, rsl)
+	expected := `caught
+3
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTypeCheckVarsChangedInBranchAreUnknown(t *testing.T) {
+	rsl := `
+a = 1
+if len("x") > 5:
+    a = "one"
+print(a + 1)
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "2\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTypeCheckVarsChangedInLoopAreUnknown(t *testing.T) {
+	rsl := `
+a = 1
+for i in range(2):
+    print(a - 1)
+    a = 5.5
+`
+	setupAndRunCode(t, rsl)
+	expected := `0
+4.5
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestTypeCheckVarsInBlocksAreScoped(t *testing.T) {
+	rsl := `
+if true:
+    a = 1
+print(a)
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Undefined variable referenced: a
+ --> test:4:7
+4 | print(a)
+  |       ^
+  = note: 'a' is defined in an earlier block, and isn't visible outside of it
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
+
+func TestTypeCheckArgTypes(t *testing.T) {
+	rsl := `
+args:
+	name string
+	count int
+a = count - name
+`
+	setupAndRunCode(t, rsl, "alice", "2")
+	expected := `error: Invalid binary operand types: int, string
+ --> test:5:11
+5 | a = count - name
+  |           ^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
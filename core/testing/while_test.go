@@ -0,0 +1,120 @@
+package testing
+
+import "testing"
+
+func TestWhileLoop(t *testing.T) {
+	rsl := `
+i = 0
+while i < 3:
+	print(i)
+	i = i + 1
+print("done")
+`
+	setupAndRunCode(t, rsl)
+	expected := `0
+1
+2
+done
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestWhileLoopNeverRunsIfConditionFalse(t *testing.T) {
+	rsl := `
+while false:
+	print("unreachable")
+print("done")
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "done\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestWhileLoopBreak(t *testing.T) {
+	rsl := `
+i = 0
+while true:
+	i = i + 1
+	if i == 3:
+		break
+	print(i)
+print("done")
+`
+	setupAndRunCode(t, rsl)
+	expected := `1
+2
+done
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestWhileLoopContinue(t *testing.T) {
+	rsl := `
+i = 0
+while i < 5:
+	i = i + 1
+	if i == 2 or i == 4:
+		continue
+	print(i)
+`
+	setupAndRunCode(t, rsl)
+	expected := `1
+3
+5
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestWhileLoopNestedInFor(t *testing.T) {
+	rsl := `
+for x in [1, 2]:
+	i = 0
+	while true:
+		if i == x:
+			break
+		i = i + 1
+	print(i)
+`
+	setupAndRunCode(t, rsl)
+	expected := `1
+2
+`
+	assertOnlyOutput(t, stdOutBuffer, expected)
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestWhileLoopTruthyCondition(t *testing.T) {
+	rsl := `
+a = [1, 2, 3]
+s = ""
+while len(a) - len(s):
+	s = s + "x"
+print(s)
+`
+	setupAndRunCode(t, rsl)
+	assertOnlyOutput(t, stdOutBuffer, "xxx\n")
+	assertNoErrors(t)
+	resetTestState()
+}
+
+func TestBreakOutsideLoopErrors(t *testing.T) {
+	rsl := `
+break
+`
+	setupAndRunCode(t, rsl)
+	expected := `error: Break statement must be inside a loop
+ --> test:2:1
+2 | break
+  | ^^^^^
+`
+	assertError(t, 1, expected)
+	resetTestState()
+}
@@ -0,0 +1,244 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RslDate is a calendar day, at midnight in the clock's location.
+type RslDate struct {
+	Time time.Time
+}
+
+// RslDateTime is an instant in time.
+type RslDateTime struct {
+	Time time.Time
+}
+
+// RslDuration is a length of time, which may be negative.
+type RslDuration time.Duration
+
+const (
+	DAY  = 24 * time.Hour
+	WEEK = 7 * DAY
+)
+
+func NewRslDate(t time.Time) RslDate {
+	year, month, day := t.Date()
+	return RslDate{Time: time.Date(year, month, day, 0, 0, 0, 0, t.Location())}
+}
+
+func (d RslDate) String() string {
+	return d.Time.Format(time.DateOnly)
+}
+
+func (d RslDate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func (d RslDateTime) String() string {
+	return d.Time.Format(time.RFC3339)
+}
+
+func (d RslDateTime) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// String formats the duration with days as the largest unit e.g. 1d2h30m, rather than Go's 26h30m0s.
+func (d RslDuration) String() string {
+	duration := time.Duration(d)
+	if duration == 0 {
+		return "0s"
+	}
+	if duration > -time.Second && duration < time.Second {
+		return duration.String()
+	}
+
+	var sb strings.Builder
+	if duration < 0 {
+		sb.WriteString("-")
+		duration = -duration
+	}
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{{DAY, "d"}, {time.Hour, "h"}, {time.Minute, "m"}}
+	for _, unit := range units {
+		if duration >= unit.size {
+			sb.WriteString(fmt.Sprintf("%d%s", duration/unit.size, unit.suffix))
+			duration %= unit.size
+		}
+	}
+	if duration > 0 {
+		sb.WriteString(strconv.FormatFloat(duration.Seconds(), 'f', -1, 64) + "s")
+	}
+	return sb.String()
+}
+
+func (d RslDuration) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func isTimeType(t RslTypeEnum) bool {
+	return t == RslDateT || t == RslDateTimeT || t == RslDurationT
+}
+
+func isTimeValue(val interface{}) bool {
+	switch val.(type) {
+	case RslDate, RslDateTime, RslDuration:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseTimeArg parses a value given for a date, datetime, or duration arg. Relative values e.g. 'yesterday' or -7d
+// are relative to the clock's current time.
+func ParseTimeArg(argType RslTypeEnum, s string) (interface{}, error) {
+	switch argType {
+	case RslDateT:
+		return ParseDate(s, RClock.Now())
+	case RslDateTimeT:
+		return ParseDateTime(s, RClock.Now())
+	case RslDurationT:
+		return ParseDuration(s)
+	default:
+		return nil, fmt.Errorf("not a time type: %s", argType.AsString())
+	}
+}
+
+var dateTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// ParseDate accepts YYYY-MM-DD, an ISO-8601 datetime (whose date is taken), 'today', 'yesterday', 'tomorrow',
+// or an offset from today e.g. -7d.
+func ParseDate(s string, now time.Time) (RslDate, error) {
+	s = strings.TrimSpace(s)
+	if t, ok := parseRelative(s, now); ok {
+		return NewRslDate(t), nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, now.Location()); err == nil {
+		return NewRslDate(t), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return NewRslDate(t), nil
+	}
+	return RslDate{}, fmt.Errorf("expected a date like 2024-01-31, 'today', 'yesterday', or an offset like -7d, got %q", s)
+}
+
+// ParseDateTime accepts ISO-8601 datetimes, with or without a time zone (the clock's is assumed if missing),
+// 'now', 'today', 'yesterday', 'tomorrow', or an offset from now e.g. -2h.
+func ParseDateTime(s string, now time.Time) (RslDateTime, error) {
+	s = strings.TrimSpace(s)
+	if t, ok := parseRelative(s, now); ok {
+		return RslDateTime{Time: t}, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return RslDateTime{Time: t}, nil
+	}
+	for _, layout := range append([]string{time.DateOnly}, dateTimeLayouts...) {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return RslDateTime{Time: t}, nil
+		}
+	}
+	return RslDateTime{}, fmt.Errorf("expected a datetime like 2024-01-31T09:30:00Z, 'now', or an offset like -2h, got %q", s)
+}
+
+// parseRelative resolves words like 'yesterday', and signed offsets like -7d, against the current time.
+func parseRelative(s string, now time.Time) (time.Time, bool) {
+	today := NewRslDate(now).Time
+	switch strings.ToLower(s) {
+	case "now":
+		return now, true
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	}
+
+	if !strings.HasPrefix(s, "-") && !strings.HasPrefix(s, "+") {
+		return time.Time{}, false
+	}
+	offset, err := ParseDuration(s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return now.Add(time.Duration(offset)), true
+}
+
+var (
+	durationPartRegex = regexp.MustCompile(`(\d+(?:\.\d+)?)(ns|us|µs|ms|s|m|h|d|w)`)
+	durationRegex     = regexp.MustCompile(`^(?:\d+(?:\.\d+)?(?:ns|us|µs|ms|s|m|h|d|w))+$`)
+	isoDurationRegex  = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+)
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  DAY,
+	"w":  WEEK,
+}
+
+// ParseDuration accepts Go-style durations extended with days and weeks e.g. 1d12h or 2w, and ISO-8601 durations
+// e.g. P1DT12H. Either may be signed. Years and months are rejected, as their length varies.
+func ParseDuration(s string) (RslDuration, error) {
+	s = strings.TrimSpace(s)
+	body := strings.TrimLeft(s, "+-")
+	negative := strings.HasPrefix(s, "-")
+	if len(s)-len(body) > 1 || body == "" {
+		return 0, durationError(s)
+	}
+
+	var total float64
+	if durationRegex.MatchString(body) {
+		for _, match := range durationPartRegex.FindAllStringSubmatch(body, -1) {
+			amount, _ := strconv.ParseFloat(match[1], 64)
+			total += amount * float64(durationUnits[match[2]])
+		}
+	} else if match := isoDurationRegex.FindStringSubmatch(strings.ToUpper(body)); match != nil && body != "P" && !strings.HasSuffix(strings.ToUpper(body), "T") {
+		for idx, unit := range []time.Duration{WEEK, DAY, time.Hour, time.Minute, time.Second} {
+			if match[idx+1] != "" {
+				amount, _ := strconv.ParseFloat(match[idx+1], 64)
+				total += amount * float64(unit)
+			}
+		}
+	} else {
+		return 0, durationError(s)
+	}
+
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q is too long", s)
+	}
+	if negative {
+		total = -total
+	}
+	return RslDuration(total), nil
+}
+
+func durationError(s string) error {
+	return fmt.Errorf("expected a duration like 1h30m, 7d, or P1DT12H, got %q", s)
+}
+
+// addToDate keeps the result a date if the duration is a whole number of days, so the date moves by calendar days.
+// Otherwise, the result is a datetime.
+func addToDate(date RslDate, duration RslDuration) interface{} {
+	if time.Duration(duration)%DAY == 0 {
+		return RslDate{Time: date.Time.AddDate(0, 0, int(time.Duration(duration)/DAY))}
+	}
+	return RslDateTime{Time: date.Time.Add(time.Duration(duration))}
+}
@@ -39,6 +39,24 @@ func (b BaseToken) GetCharLineStart() int {
 type StringLiteralToken struct {
 	BaseToken
 	Literal string
+	// the literal split into text and {...} interpolations, with escapes processed
+	Segments []StringSegment
+}
+
+// StringSegment is a run of text in a string literal, or an interpolation within it.
+type StringSegment struct {
+	Text          string
+	Interpolation *InterpolationTokens // nil for text
+}
+
+// InterpolationTokens is an interpolation e.g. {price:.2f}, with its expression lexed by the lexer, so errors in it
+// point to where it is in the source. It's only parsed where the string is used as an expression, so other strings,
+// such as arg regexes, may still use braces freely.
+type InterpolationTokens struct {
+	Token  Token   // the whole {...}
+	Expr   []Token // ends with an EOF token
+	Format Token   // nil if there's no format e.g. the ".2f" in {price:.2f}
+	Closed bool
 }
 
 type IntLiteralToken struct {
@@ -97,6 +115,7 @@ func NewStringLiteralToken(
 	line int,
 	charLineStart int,
 	literal string,
+	segments []StringSegment,
 ) Token {
 	return &StringLiteralToken{
 		BaseToken: BaseToken{
@@ -106,7 +125,8 @@ func NewStringLiteralToken(
 			Line:          line,
 			CharLineStart: charLineStart,
 		},
-		Literal: literal,
+		Literal:  literal,
+		Segments: segments,
 	}
 }
 
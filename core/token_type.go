@@ -23,20 +23,24 @@ const (
 	AT            TokenType = "AT" // @
 	LESS          TokenType = "LESS"
 	GREATER       TokenType = "GREATER"
-	SLASH         TokenType = "SLASH" // /
-	STAR          TokenType = "STAR"  // *
+	SLASH         TokenType = "SLASH"   // /
+	STAR          TokenType = "STAR"    // *
+	PERCENT       TokenType = "PERCENT" // %
 
 	// Two-character tokens
 
-	BRACKETS      TokenType = "BRACKETS"
-	EQUAL_EQUAL   TokenType = "EQUAL_EQUAL"
-	NOT_EQUAL     TokenType = "NOT_EQUAL"
-	LESS_EQUAL    TokenType = "LESS_EQUAL"
-	GREATER_EQUAL TokenType = "GREATER_EQUAL"
-	PLUS_EQUAL    TokenType = "PLUS_EQUAL"
-	MINUS_EQUAL   TokenType = "MINUS_EQUAL"
-	STAR_EQUAL    TokenType = "STAR_EQUAL"
-	SLASH_EQUAL   TokenType = "SLASH_EQUAL"
+	BRACKETS          TokenType = "BRACKETS"
+	EQUAL_EQUAL       TokenType = "EQUAL_EQUAL"
+	NOT_EQUAL         TokenType = "NOT_EQUAL"
+	LESS_EQUAL        TokenType = "LESS_EQUAL"
+	GREATER_EQUAL     TokenType = "GREATER_EQUAL"
+	PLUS_EQUAL        TokenType = "PLUS_EQUAL"
+	MINUS_EQUAL       TokenType = "MINUS_EQUAL"
+	STAR_EQUAL        TokenType = "STAR_EQUAL"
+	SLASH_EQUAL       TokenType = "SLASH_EQUAL"
+	PERCENT_EQUAL     TokenType = "PERCENT_EQUAL"
+	STAR_STAR         TokenType = "STAR_STAR"         // **
+	QUESTION_QUESTION TokenType = "QUESTION_QUESTION" // ??
 
 	// N-character tokens
 	INDENT TokenType = "INDENT"
@@ -49,6 +53,7 @@ const (
 	INT_LITERAL       TokenType = "INT_LITERAL"
 	FLOAT_LITERAL     TokenType = "FLOAT_LITERAL"
 	BOOL_LITERAL      TokenType = "BOOL_LITERAL"
+	NULL_LITERAL      TokenType = "NULL_LITERAL"
 	FILE_HEADER       TokenType = "FILE_HEADER"
 	ARG_COMMENT       TokenType = "ARG_COMMENT"
 	JSON_PATH_ELEMENT TokenType = "JSON_PATH_ELEMENT"
@@ -56,6 +61,7 @@ const (
 	// Keywords
 
 	FOR      TokenType = "FOR"
+	WHILE    TokenType = "WHILE"
 	BREAK    TokenType = "BREAK"
 	CONTINUE TokenType = "CONTINUE"
 	IN       TokenType = "IN"
@@ -72,6 +78,12 @@ const (
 	IF       TokenType = "IF"
 	ELSE     TokenType = "ELSE"
 	RESOURCE TokenType = "RESOURCE"
+	IMPORT   TokenType = "IMPORT"
+	AS       TokenType = "AS"
+	TRY      TokenType = "TRY"
+	CATCH    TokenType = "CATCH"
+	IS       TokenType = "IS"
+	NOT      TokenType = "NOT"
 
 	// only in Args block
 	STRING   TokenType = "STRING"
@@ -82,6 +94,16 @@ const (
 	REQUIRES TokenType = "REQUIRES"
 	ONE_OF   TokenType = "ONE_OF"
 	REGEX    TokenType = "REGEX" // todo this is pretty sad to not make available for users as Flag
+	AT_LEAST TokenType = "AT_LEAST"
+	EXACTLY  TokenType = "EXACTLY"
+	AT_MOST  TokenType = "AT_MOST"
+	ENV      TokenType = "ENV"
+	SECRET   TokenType = "SECRET"
+	DATE     TokenType = "DATE"
+	DATETIME TokenType = "DATETIME"
+	DURATION TokenType = "DURATION"
+	PATH     TokenType = "PATH"
+	DIR      TokenType = "DIR"
 
 	// only in rad block
 	FIELDS   TokenType = "FIELDS"
@@ -0,0 +1,1067 @@
+package core
+
+import (
+	"fmt"
+	"github.com/samber/lo"
+	"os"
+	"slices"
+)
+
+// TypeChecker analyses a parsed script before it runs, so that undefined variables, type mismatches, and bad
+// function calls are all reported up front, rather than one at a time and possibly only after slow requests.
+// It's conservative: anything it cannot be sure would fail is left for the interpreter to check at runtime.
+type TypeChecker struct {
+	scope  *typeScope
+	errors []RslError
+	// likely mistakes which won't fail the script, surfaced by 'rad check'
+	warnings []RslError
+	// >0 while checking code whose errors wouldn't end the script, e.g. in try blocks, or while re-checking a
+	// loop body. Errors are not reported.
+	suppressed int
+	// >0 during the first of the two passes over a loop body. Nothing is reported, not even warnings.
+	repeating int
+	// >0 while checking the cases of a switch without discriminator, which may reference undefined variables
+	// on purpose, as that's how the case is chosen.
+	inChoiceSwitch int
+	args           []Token
+	usedVars       map[string]bool
+	// variables defined in blocks which have since ended, to explain why they're undefined after
+	endedBlockVars map[string]bool
+	// absolute paths of the modules being checked, in import order, so cycles are left for the interpreter to report
+	importStack []string
+	// the exports of modules already checked, keyed by absolute path
+	checkedModules map[string]moduleExports
+}
+
+// moduleExports are the types of a module's top-level variables, or nil if they cannot be known until it's run.
+type moduleExports map[string]*RslTypeEnum
+
+// typeScope mirrors the runtime Env. A nil type means the variable is defined, but its type cannot be known.
+type typeScope struct {
+	vars      map[string]*RslTypeEnum
+	modules   map[string]moduleExports
+	enclosing *typeScope
+}
+
+func newTypeScope(enclosing *typeScope) *typeScope {
+	return &typeScope{
+		vars:      make(map[string]*RslTypeEnum),
+		modules:   make(map[string]moduleExports),
+		enclosing: enclosing,
+	}
+}
+
+func NewTypeChecker() *TypeChecker {
+	return newModuleChecker(nil, make(map[string]moduleExports))
+}
+
+func newModuleChecker(importStack []string, checkedModules map[string]moduleExports) *TypeChecker {
+	return &TypeChecker{
+		scope:          newTypeScope(nil),
+		usedVars:       make(map[string]bool),
+		endedBlockVars: make(map[string]bool),
+		importStack:    importStack,
+		checkedModules: checkedModules,
+	}
+}
+
+// Check returns all the errors found in the given statements, in the order they appear.
+func (c *TypeChecker) Check(statements []Stmt) []RslError {
+	c.checkStmts(statements)
+	for _, arg := range c.args {
+		if !c.usedVars[arg.GetLexeme()] {
+			c.warn(arg, fmt.Sprintf("Arg '%s' is never used", arg.GetLexeme()))
+		}
+	}
+	return c.errors
+}
+
+// Warnings returns the warnings found by Check.
+func (c *TypeChecker) Warnings() []RslError {
+	return c.warnings
+}
+
+// == Exprs ==
+
+func (c *TypeChecker) VisitExprLoaExpr(loa ExprLoa) interface{} {
+	switch value := loa.Value.(type) {
+	case *LoaLiteral:
+		switch literal := value.Value.(type) {
+		case StringLiteral:
+			c.checkInterpolation(literal)
+			return typeOf(RslStringT)
+		case IntLiteral:
+			return typeOf(RslIntT)
+		case FloatLiteral:
+			return typeOf(RslFloatT)
+		case BoolLiteral:
+			return typeOf(RslBoolT)
+		case NullLiteral:
+			return typeOf(RslNullT)
+		}
+	case *LoaArray:
+		switch value.Value.(type) {
+		case StringArrayLiteral:
+			return typeOf(RslStringArrayT)
+		case IntArrayLiteral:
+			return typeOf(RslIntArrayT)
+		case FloatArrayLiteral:
+			return typeOf(RslFloatArrayT)
+		case BoolArrayLiteral:
+			return typeOf(RslBoolArrayT)
+		default:
+			return typeOf(RslArrayT)
+		}
+	}
+	return unknownType
+}
+
+func (c *TypeChecker) VisitArrayExprExpr(expr ArrayExpr) interface{} {
+	for _, v := range expr.Values {
+		c.typeOf(v)
+	}
+	return typeOf(RslArrayT)
+}
+
+func (c *TypeChecker) VisitArrayAccessExpr(access ArrayAccess) interface{} {
+	arrayType := c.typeOf(access.Array)
+	indexType := c.typeOf(access.Index)
+
+	if isKnown(indexType) && *indexType != RslIntT {
+		c.error(access.OpenBracketToken, fmt.Sprintf("Array index must be an int, got %s", indexType.AsString()))
+	}
+
+	if !isKnown(arrayType) {
+		return unknownType
+	}
+
+	switch *arrayType {
+	case RslStringArrayT:
+		return typeOf(RslStringT)
+	case RslIntArrayT:
+		return typeOf(RslIntT)
+	case RslFloatArrayT:
+		return typeOf(RslFloatT)
+	case RslBoolArrayT:
+		return typeOf(RslBoolT)
+	case RslArrayT:
+		return unknownType
+	default:
+		c.error(access.OpenBracketToken, fmt.Sprintf("Cannot index into %s", arrayType.AsString()))
+		return unknownType
+	}
+}
+
+func (c *TypeChecker) VisitFunctionCallExpr(call FunctionCall) interface{} {
+	returnTypes := c.checkCall(call, false)
+	if len(returnTypes) == 1 {
+		return returnTypes[0]
+	}
+	return unknownType
+}
+
+func (c *TypeChecker) VisitVariableExpr(variable Variable) interface{} {
+	varType, ok := c.lookup(variable.Name.GetLexeme())
+	if !ok {
+		c.undefinedVariable(variable.Name, variable.Name.GetLexeme())
+	}
+	return varType
+}
+
+func (c *TypeChecker) VisitBinaryExpr(binary Binary) interface{} {
+	left := c.typeOf(binary.Left)
+	right := c.typeOf(binary.Right)
+	return c.binaryType(binary.Operator, binary.Operator.GetType(), left, right)
+}
+
+func (c *TypeChecker) VisitLogicalExpr(logical Logical) interface{} {
+	left := c.typeOf(logical.Left)
+	right := c.typeOf(logical.Right)
+
+	if logical.Operator.GetType() != QUESTION_QUESTION {
+		return typeOf(RslBoolT)
+	}
+
+	if isKnown(left) && *left != RslNullT {
+		return left
+	}
+	if isKnown(left) && *left == RslNullT {
+		return right
+	}
+	return unknownType
+}
+
+func (c *TypeChecker) VisitGroupingExpr(grouping Grouping) interface{} {
+	return c.typeOf(grouping.Value)
+}
+
+func (c *TypeChecker) VisitUnaryExpr(unary Unary) interface{} {
+	valueType := c.typeOf(unary.Right)
+	isNot := unary.Operator.GetType() == EXCLAMATION
+
+	if !isKnown(valueType) || *valueType == RslNullT {
+		if isNot {
+			return typeOf(RslBoolT)
+		}
+		return unknownType
+	}
+
+	switch *valueType {
+	case RslBoolT:
+		if !isNot {
+			c.error(unary.Operator, "Invalid logical operator, only 'not' is allowed")
+		}
+		return typeOf(RslBoolT)
+	case RslIntT, RslFloatT:
+		if isNot {
+			c.error(unary.Operator, "Invalid number unary operation, only + and - are allowed")
+		}
+		return valueType
+	default:
+		c.error(unary.Operator, "Invalid unary operands, only bool, float, or int is allowed")
+		return unknownType
+	}
+}
+
+func (c *TypeChecker) VisitListComprehensionExpr(comp ListComprehension) interface{} {
+	elementType := c.iterableElementType(comp.For, c.typeOf(comp.Range), "List comprehension range must be an array")
+	c.checkShadowing(comp.Identifier1, comp.Identifier2)
+
+	c.withChildScope(func() {
+		c.defineLoopIdentifiers(comp.Identifier1, comp.Identifier2, elementType)
+		if comp.Condition != nil {
+			c.typeOf(*comp.Condition)
+		}
+		c.typeOf(comp.Expression)
+	})
+	return typeOf(RslArrayT)
+}
+
+func (c *TypeChecker) VisitModuleAccessExpr(access ModuleAccess) interface{} {
+	exports, ok := c.scope.module(access.Module.GetLexeme())
+	if !ok {
+		c.errorWithHint(access.Module, fmt.Sprintf("Undefined module referenced: %v", access.Module.GetLexeme()),
+			didYouMean(access.Module.GetLexeme(), c.scope.moduleNames()))
+		return unknownType
+	}
+	if exports == nil {
+		return unknownType
+	}
+
+	name := access.Name.GetLexeme()
+	varType, ok := exports[name]
+	if !ok {
+		c.errorWithHint(access.Name, fmt.Sprintf("Module '%s' has no variable '%s'", access.Module.GetLexeme(), name),
+			didYouMean(name, lo.Keys(exports)))
+		return unknownType
+	}
+	return varType
+}
+
+func (c *TypeChecker) VisitFallibleExpr(fallible Fallible) interface{} {
+	c.suppressed++
+	valueType := c.typeOf(fallible.Value)
+	c.suppressed--
+	fallbackType := c.typeOf(fallible.Fallback)
+	if sameType(valueType, fallbackType) {
+		return valueType
+	}
+	return unknownType
+}
+
+func (c *TypeChecker) VisitNullCheckExpr(check NullCheck) interface{} {
+	c.typeOf(check.Value)
+	return typeOf(RslBoolT)
+}
+
+// == Stmts ==
+
+func (c *TypeChecker) VisitEmptyStmt(Empty) {}
+
+func (c *TypeChecker) VisitFileHeaderStmt(FileHeader) {}
+
+func (c *TypeChecker) VisitBreakStmtStmt(BreakStmt) {}
+
+func (c *TypeChecker) VisitContinueStmtStmt(ContinueStmt) {}
+
+func (c *TypeChecker) VisitExprStmtStmt(stmt ExprStmt) {
+	c.typeOf(stmt.Expression)
+}
+
+func (c *TypeChecker) VisitFunctionStmtStmt(stmt FunctionStmt) {
+	c.checkCall(stmt.Call, true)
+}
+
+func (c *TypeChecker) VisitPrimaryAssignStmt(assign PrimaryAssign) {
+	var valueTypes []*RslTypeEnum
+	if len(assign.Identifiers) == 1 {
+		valueTypes = []*RslTypeEnum{c.typeOf(assign.Initializer)}
+	} else if call, ok := assign.Initializer.(FunctionCall); ok {
+		valueTypes = c.checkCall(call, false)
+	} else {
+		c.typeOf(assign.Initializer)
+	}
+
+	for idx, identifier := range assign.Identifiers {
+		var valueType *RslTypeEnum
+		if idx < len(valueTypes) {
+			valueType = valueTypes[idx]
+		}
+		c.assign(identifier, assign.VarTypes[idx], valueType)
+	}
+}
+
+func (c *TypeChecker) VisitCompoundAssignStmt(assign CompoundAssign) {
+	varType, ok := c.lookup(assign.Name.GetLexeme())
+	if !ok {
+		c.undefinedVariable(assign.Name, assign.Name.GetLexeme())
+	}
+	valueType := c.typeOf(assign.Value)
+
+	var operatorType TokenType
+	switch assign.Operator.GetType() {
+	case PLUS_EQUAL:
+		operatorType = PLUS
+	case MINUS_EQUAL:
+		operatorType = MINUS
+	case STAR_EQUAL:
+		operatorType = STAR
+	case SLASH_EQUAL:
+		operatorType = SLASH
+	case PERCENT_EQUAL:
+		operatorType = PERCENT
+	}
+	c.scope.set(assign.Name.GetLexeme(), c.binaryType(assign.Operator, operatorType, varType, valueType))
+}
+
+func (c *TypeChecker) VisitArgBlockStmt(block ArgBlock) {
+	for _, stmt := range block.Stmts {
+		stmt.Accept(c)
+	}
+	_, errs := ArgConstraints(block)
+	c.errors = append(c.errors, errs...)
+}
+
+func (c *TypeChecker) VisitArgDeclarationArgStmt(decl ArgDeclaration) {
+	argType := typeOf(decl.ArgType.Type)
+	hasDefault := NotNil(decl.Default, func() LiteralOrArray { return nil })
+	if decl.IsOptional && !hasDefault && decl.ArgType.Type != RslBoolT {
+		// will be null if not given
+		argType = unknownType
+	}
+	c.scope.set(decl.Identifier.GetLexeme(), argType)
+	c.args = append(c.args, decl.Identifier)
+
+	if decl.Enum != nil && hasDefault {
+		c.checkDefaultInEnum(decl)
+	}
+	if isTimeType(decl.ArgType.Type) && hasDefault {
+		c.checkTimeDefault(decl)
+	}
+}
+
+// checkTimeDefault errors if a date, datetime, or duration arg's default could never be parsed.
+func (c *TypeChecker) checkTimeDefault(decl ArgDeclaration) {
+	loa, ok := (*decl.Default).(*LoaLiteral)
+	if !ok {
+		return
+	}
+	literal, ok := loa.Value.(StringLiteral)
+	if !ok {
+		return
+	}
+	if _, err := ParseTimeArg(decl.ArgType.Type, literal.Value.Literal); err != nil {
+		c.error(&literal.Value, fmt.Sprintf("Invalid default for '%s': %v", decl.Identifier.GetLexeme(), err))
+	}
+}
+
+func (c *TypeChecker) checkDefaultInEnum(decl ArgDeclaration) {
+	var defaults []StringLiteral
+	switch loa := (*decl.Default).(type) {
+	case *LoaLiteral:
+		if literal, ok := loa.Value.(StringLiteral); ok {
+			defaults = append(defaults, literal)
+		}
+	case *LoaArray:
+		if array, ok := loa.Value.(StringArrayLiteral); ok {
+			defaults = array.Values
+		}
+	}
+
+	valid := lo.Map(decl.Enum.Values, func(v StringLiteral, _ int) string { return v.Value.Literal })
+	for _, literal := range defaults {
+		if !lo.Contains(valid, literal.Value.Literal) {
+			c.errorWithHint(&literal.Value, fmt.Sprintf("Default %q is not one of the values '%s' is restricted to",
+				literal.Value.Literal, decl.Identifier.GetLexeme()), didYouMean(literal.Value.Literal, valid))
+		}
+	}
+}
+
+// constraints are checked together with the declarations they refer to, in VisitArgBlockStmt. The args they
+// reference count as used, as they change what the script accepts.
+
+func (c *TypeChecker) VisitArgRequiresArgStmt(requires ArgRequires) {
+	c.useArgs(append([]Token{requires.Identifier}, requires.Required...))
+}
+
+func (c *TypeChecker) VisitArgOneOfArgStmt(oneOf ArgOneOf) {
+	c.useArgs(oneOf.Identifiers)
+}
+
+func (c *TypeChecker) VisitArgCountArgStmt(count ArgCount) {
+	c.useArgs(count.Identifiers)
+}
+
+func (c *TypeChecker) VisitArgRegexArgStmt(regex ArgRegex) {
+	c.useArgs(regex.Identifiers)
+}
+
+func (c *TypeChecker) VisitArgRangeArgStmt(argRange ArgRange) {
+	c.useArgs([]Token{argRange.Identifier})
+}
+
+func (c *TypeChecker) useArgs(identifiers []Token) {
+	for _, identifier := range identifiers {
+		c.usedVars[identifier.GetLexeme()] = true
+	}
+}
+
+func (c *TypeChecker) VisitRadBlockStmt(block RadBlock) {
+	if block.Source != nil {
+		c.typeOf(*block.Source)
+	}
+
+	fields := make(map[string]bool)
+	for _, stmt := range block.Stmts {
+		stmt.Accept(c)
+		if f, ok := stmt.(*Fields); ok {
+			for _, identifier := range f.Identifiers {
+				fields[identifier.GetLexeme()] = true
+			}
+		}
+	}
+
+	for _, stmt := range block.Stmts {
+		var identifiers []Token
+		switch s := stmt.(type) {
+		case *Sort:
+			identifiers = s.Identifiers
+		case *FieldMods:
+			identifiers = s.Identifiers
+		}
+		for _, identifier := range identifiers {
+			if !fields[identifier.GetLexeme()] {
+				c.warn(identifier, fmt.Sprintf("Field '%s' is not in the fields statement", identifier.GetLexeme()))
+			}
+		}
+	}
+}
+
+func (c *TypeChecker) VisitFieldsRadStmt(fields Fields) {
+	for _, identifier := range fields.Identifiers {
+		if _, ok := c.lookup(identifier.GetLexeme()); !ok {
+			c.undefinedVariable(identifier, identifier.GetLexeme())
+		}
+	}
+}
+
+func (c *TypeChecker) VisitSortRadStmt(Sort) {
+	// sorts refer to columns, not variables
+}
+
+func (c *TypeChecker) VisitFieldModsRadStmt(mods FieldMods) {
+	for _, mod := range mods.Mods {
+		mod.Accept(c)
+	}
+}
+
+func (c *TypeChecker) VisitTruncateRadFieldModStmt(truncate Truncate) {
+	c.typeOf(truncate.Value)
+}
+
+func (c *TypeChecker) VisitColorRadFieldModStmt(color Color) {
+	c.typeOf(color.ColorValue)
+	c.typeOf(color.Regex)
+}
+
+func (c *TypeChecker) VisitJsonPathAssignStmt(assign JsonPathAssign) {
+	fieldType := unknownType
+	for _, element := range assign.Path.elements {
+		if element.token.IsArray || element.token.GetLexeme() == WILDCARD {
+			fieldType = typeOf(RslArrayT)
+			break
+		}
+	}
+	c.scope.set(assign.Identifier.GetLexeme(), fieldType)
+}
+
+func (c *TypeChecker) VisitSwitchBlockStmtStmt(stmt SwitchBlockStmt) {
+	c.checkSwitchBlock(stmt.Block)
+}
+
+func (c *TypeChecker) VisitSwitchAssignmentStmt(assignment SwitchAssignment) {
+	outputTypes := c.checkSwitchBlock(assignment.Block)
+	for idx, identifier := range assignment.Identifiers {
+		var outputType *RslTypeEnum
+		if idx < len(outputTypes) {
+			outputType = outputTypes[idx]
+		}
+		c.assign(identifier, assignment.VarTypes[idx], outputType)
+	}
+}
+
+func (c *TypeChecker) VisitBlockStmt(block Block) {
+	c.withChildScope(func() {
+		c.checkStmts(block.Stmts)
+	})
+}
+
+func (c *TypeChecker) VisitIfStmtStmt(stmt IfStmt) {
+	for _, ifCase := range stmt.Cases {
+		c.typeOf(ifCase.Condition)
+		c.checkBranch(func() { ifCase.Body.Accept(c) })
+	}
+	if stmt.ElseBlock != nil {
+		c.checkBranch(func() { stmt.ElseBlock.Accept(c) })
+	}
+}
+
+func (c *TypeChecker) VisitIfCaseStmt(IfCase) {
+	// checked as part of the IfStmt
+}
+
+func (c *TypeChecker) VisitForStmtStmt(stmt ForStmt) {
+	elementType := c.iterableElementType(stmt.ForToken, c.typeOf(stmt.Range), "For loop range must be an array")
+	c.checkShadowing(stmt.Identifier1, stmt.Identifier2)
+
+	c.withChildScope(func() {
+		c.checkLoop(func() {
+			c.defineLoopIdentifiers(stmt.Identifier1, stmt.Identifier2, elementType)
+			stmt.Body.Accept(c)
+		})
+	})
+}
+
+func (c *TypeChecker) VisitWhileStmtStmt(stmt WhileStmt) {
+	c.checkLoop(func() {
+		c.typeOf(stmt.Condition)
+		stmt.Body.Accept(c)
+	})
+}
+
+func (c *TypeChecker) VisitImportStmtStmt(stmt ImportStmt) {
+	c.scope.modules[moduleNamespace(stmt)] = c.checkImport(stmt)
+}
+
+// checkImport type checks the imported module, exiting with any errors in it, and returns its exports. Modules which
+// cannot be loaded have unknown exports, and are left for the interpreter to report.
+func (c *TypeChecker) checkImport(stmt ImportStmt) moduleExports {
+	rawPath := stmt.Path.Value.Literal
+	path, ok := resolveModulePath(rawPath)
+	if !ok || lo.Contains(c.importStack, path) {
+		return nil
+	}
+	if exports, ok := c.checkedModules[path]; ok {
+		return exports
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var exports moduleExports
+	inModule(rawPath, path, string(source), func() {
+		checker := newModuleChecker(append(slices.Clone(c.importStack), path), c.checkedModules)
+		checkModule(string(source), checker)
+		exports = checker.scope.vars
+	})
+	c.checkedModules[path] = exports
+	return exports
+}
+
+func (c *TypeChecker) VisitTryStmtStmt(stmt TryStmt) {
+	c.suppressed++
+	c.checkBranch(func() { stmt.TryBlock.Accept(c) })
+	c.suppressed--
+
+	if stmt.ErrIdentifier != nil {
+		c.checkShadowing(*stmt.ErrIdentifier, stmt.LocIdentifier)
+	}
+	c.withChildScope(func() {
+		if stmt.ErrIdentifier != nil {
+			c.scope.set((*stmt.ErrIdentifier).GetLexeme(), typeOf(RslStringT))
+		}
+		if stmt.LocIdentifier != nil {
+			c.scope.set((*stmt.LocIdentifier).GetLexeme(), typeOf(RslStringT))
+		}
+		c.checkBranch(func() { stmt.CatchBlock.Accept(c) })
+	})
+}
+
+// == Switch stmts ==
+
+type switchTypeChecker struct {
+	c            *TypeChecker
+	outputTypes  []*RslTypeEnum
+	checkedCases int
+}
+
+func (s *switchTypeChecker) VisitSwitchCaseSwitchStmt(switchCase SwitchCase) {
+	s.addOutputs(switchCase.Values)
+}
+
+func (s *switchTypeChecker) VisitSwitchDefaultSwitchStmt(switchDefault SwitchDefault) {
+	s.addOutputs(switchDefault.Values)
+}
+
+func (s *switchTypeChecker) addOutputs(values []Expr) {
+	for idx, value := range values {
+		valueType := s.c.typeOf(value)
+		if s.checkedCases == 0 {
+			s.outputTypes = append(s.outputTypes, valueType)
+		} else if idx < len(s.outputTypes) && !sameType(s.outputTypes[idx], valueType) {
+			s.outputTypes[idx] = unknownType
+		}
+	}
+	s.checkedCases++
+}
+
+// checkSwitchBlock returns the types of the values the switch outputs, where they're the same for every case.
+func (c *TypeChecker) checkSwitchBlock(block SwitchBlock) []*RslTypeEnum {
+	hasDiscriminator := block.Discriminator != nil && *block.Discriminator != nil
+	if hasDiscriminator {
+		discriminator := *block.Discriminator
+		if _, ok := c.lookup(discriminator.GetLexeme()); !ok {
+			c.undefinedVariable(discriminator, discriminator.GetLexeme())
+		}
+	} else {
+		c.inChoiceSwitch++
+		defer func() { c.inChoiceSwitch-- }()
+	}
+
+	s := &switchTypeChecker{c: c}
+	for _, stmt := range block.Stmts {
+		stmt.Accept(s)
+	}
+	return s.outputTypes
+}
+
+// == helpers ==
+
+var unknownType *RslTypeEnum = nil
+
+func typeOf(t RslTypeEnum) *RslTypeEnum {
+	return &t
+}
+
+func isKnown(t *RslTypeEnum) bool {
+	return t != nil
+}
+
+func sameType(a *RslTypeEnum, b *RslTypeEnum) bool {
+	return isKnown(a) && isKnown(b) && *a == *b
+}
+
+func isNumber(t RslTypeEnum) bool {
+	return t == RslIntT || t == RslFloatT
+}
+
+func isScalar(t RslTypeEnum) bool {
+	return t == RslStringT || t == RslIntT || t == RslFloatT || t == RslBoolT
+}
+
+func (c *TypeChecker) typeOf(expr Expr) *RslTypeEnum {
+	t, _ := expr.Accept(c).(*RslTypeEnum)
+	return t
+}
+
+func (c *TypeChecker) error(token Token, msg string) {
+	c.errorWithHint(token, msg, "")
+}
+
+func (c *TypeChecker) errorWithHint(token Token, msg string, hint string) {
+	if c.suppressed > 0 || c.repeating > 0 {
+		return
+	}
+	c.errors = append(c.errors, RslError{Token: token, Msg: msg, Hint: hint})
+}
+
+func (c *TypeChecker) undefinedVariable(token Token, name string) {
+	if c.suppressed > 0 || c.repeating > 0 {
+		return
+	}
+	err := RslError{
+		Token: token,
+		Msg:   fmt.Sprintf("Undefined variable referenced: %v", name),
+		Hint:  didYouMean(name, c.scope.varNames()),
+	}
+	if c.endedBlockVars[name] {
+		err.Note = fmt.Sprintf("'%s' is defined in an earlier block, and isn't visible outside of it", name)
+	}
+	c.errors = append(c.errors, err)
+}
+
+func (c *TypeChecker) warn(token Token, msg string) {
+	if c.repeating > 0 {
+		return
+	}
+	c.warnings = append(c.warnings, RslError{Token: token, Msg: msg})
+}
+
+// lookup finds a variable's type, noting that it's used.
+func (c *TypeChecker) lookup(name string) (*RslTypeEnum, bool) {
+	c.usedVars[name] = true
+	return c.scope.lookup(name)
+}
+
+func (c *TypeChecker) checkStmts(stmts []Stmt) {
+	var exitToken Token
+	for _, stmt := range stmts {
+		if exitToken != nil {
+			// pointed at the first unreachable statement, or the exit() if it cannot be found
+			c.warn(lo.CoalesceOrEmpty(stmtToken(stmt), exitToken), "Code after exit() is unreachable")
+			exitToken = nil
+		}
+		stmt.Accept(c)
+		if call, ok := stmt.(*FunctionStmt); ok && call.Call.Function.GetLexeme() == EXIT {
+			exitToken = call.Call.Function
+		}
+	}
+}
+
+// stmtToken is the token a statement starts with, or nil if it isn't known.
+func stmtToken(stmt Stmt) Token {
+	switch s := stmt.(type) {
+	case *FunctionStmt:
+		return s.Call.Function
+	case *PrimaryAssign:
+		return s.Identifiers[0]
+	case *CompoundAssign:
+		return s.Name
+	case *JsonPathAssign:
+		return s.Identifier
+	case *SwitchAssignment:
+		return s.Identifiers[0]
+	case *RadBlock:
+		return s.RadKeyword
+	case *ImportStmt:
+		return s.ImportToken
+	case IfStmt:
+		return s.Cases[0].IfToken
+	case *TryStmt:
+		return s.TryToken
+	case ForStmt:
+		return s.ForToken
+	case *WhileStmt:
+		return s.WhileToken
+	case *BreakStmt:
+		return s.BreakToken
+	case *ContinueStmt:
+		return s.ContinueToken
+	default:
+		return nil
+	}
+}
+
+// checkShadowing warns about loop and catch identifiers which overwrite an existing variable.
+func (c *TypeChecker) checkShadowing(identifier1 Token, identifier2 *Token) {
+	identifiers := []Token{identifier1}
+	if identifier2 != nil {
+		identifiers = append(identifiers, *identifier2)
+	}
+	for _, identifier := range identifiers {
+		if _, ok := c.scope.lookup(identifier.GetLexeme()); ok {
+			c.warn(identifier, fmt.Sprintf("'%s' shadows an existing variable, which it will overwrite",
+				identifier.GetLexeme()))
+		}
+	}
+}
+
+// checkCall checks a call's arguments against the function's signature, returning the types of its return values.
+func (c *TypeChecker) checkCall(call FunctionCall, isStmt bool) []*RslTypeEnum {
+	var argTypes []*RslTypeEnum
+	for _, arg := range call.Args {
+		argTypes = append(argTypes, c.typeOf(arg))
+	}
+	for _, arg := range call.NamedArgs {
+		argTypes = append(argTypes, c.typeOf(arg.Value))
+	}
+
+	name := call.Function.GetLexeme()
+	signature, ok := GetSignature(name)
+	if !ok {
+		c.errorWithHint(call.Function, fmt.Sprintf("Unknown function: %v", name), didYouMean(name, lo.Keys(FunctionSignatures)))
+		return nil
+	}
+
+	_, errs := bindArgs(call, signature, argTypes)
+	for _, err := range errs {
+		c.errorWithHint(err.token, err.msg, err.hint)
+	}
+
+	if isStmt {
+		return signature.ReturnTypes
+	}
+
+	if signature.IsVoid() {
+		c.error(call.Function, fmt.Sprintf("%s() does not return a value", name))
+		return nil
+	}
+
+	numExpected := call.NumExpectedReturnValues
+	if signature.VariableReturns {
+		return make([]*RslTypeEnum, max(numExpected, 1))
+	}
+
+	if numExpected != NO_NUM_RETURN_VALUES_CONSTRAINT && numExpected != len(signature.ReturnTypes) {
+		c.error(call.Function, fmt.Sprintf("%v() returns %v return values, but %v are expected",
+			name, len(signature.ReturnTypes), numExpected))
+	}
+	return signature.ReturnTypes
+}
+
+// binaryType mirrors MainInterpreter.execute for the operand types it can be sure about.
+func (c *TypeChecker) binaryType(token Token, operator TokenType, left *RslTypeEnum, right *RslTypeEnum) *RslTypeEnum {
+	isComparison := operator == GREATER || operator == GREATER_EQUAL || operator == LESS ||
+		operator == LESS_EQUAL || operator == EQUAL_EQUAL || operator == NOT_EQUAL
+
+	if !isKnown(left) || !isKnown(right) || *left == RslNullT || *right == RslNullT {
+		if operator == EQUAL_EQUAL || operator == NOT_EQUAL {
+			return typeOf(RslBoolT)
+		}
+		return unknownType
+	}
+
+	l := *left
+	r := *right
+	switch {
+	case isTimeType(l) || isTimeType(r):
+		return c.timeBinaryType(token, operator, l, r, isComparison)
+	case l == RslBoolT:
+		c.error(token, "Invalid binary operator for bool")
+	case isNumber(l) && isNumber(r):
+		if isComparison {
+			return typeOf(RslBoolT)
+		}
+		if l == RslIntT && r == RslIntT {
+			return typeOf(RslIntT)
+		}
+		return typeOf(RslFloatT)
+	case isNumber(l):
+		c.error(token, fmt.Sprintf("Invalid binary operand types: %s, %s", l.AsString(), r.AsString()))
+	case l == RslStringT && isScalar(r):
+		if operator == PLUS {
+			return typeOf(RslStringT)
+		}
+		if r == RslStringT && (operator == EQUAL_EQUAL || operator == NOT_EQUAL) {
+			return typeOf(RslBoolT)
+		}
+		c.error(token, fmt.Sprintf("Invalid binary operator for string, %s", r.AsString()))
+	case l == RslStringT:
+		c.error(token, fmt.Sprintf("Invalid binary operand types: %s, %s", l.AsString(), r.AsString()))
+	case operator == PLUS:
+		// arrays, which are appended to
+		return left
+	}
+	return unknownType
+}
+
+// timeBinaryType mirrors MainInterpreter.executeTime
+func (c *TypeChecker) timeBinaryType(token Token, operator TokenType, l RslTypeEnum, r RslTypeEnum, isComparison bool) *RslTypeEnum {
+	isInstant := func(t RslTypeEnum) bool { return t == RslDateT || t == RslDateTimeT }
+
+	switch {
+	case operator == PLUS && (l == RslStringT || r == RslStringT):
+		return typeOf(RslStringT)
+	case operator == EQUAL_EQUAL || operator == NOT_EQUAL:
+		return typeOf(RslBoolT)
+	case isInstant(l) && isInstant(r):
+		if operator == MINUS {
+			return typeOf(RslDurationT)
+		}
+		if isComparison {
+			return typeOf(RslBoolT)
+		}
+	case isInstant(l) && r == RslDurationT && (operator == PLUS || operator == MINUS),
+		l == RslDurationT && isInstant(r) && operator == PLUS:
+		if l == RslDateTimeT || r == RslDateTimeT {
+			return typeOf(RslDateTimeT)
+		}
+		// stays a date only if the duration is whole days
+		return unknownType
+	case l == RslDurationT && r == RslDurationT:
+		switch {
+		case operator == PLUS || operator == MINUS:
+			return typeOf(RslDurationT)
+		case operator == SLASH:
+			return typeOf(RslFloatT)
+		case isComparison:
+			return typeOf(RslBoolT)
+		}
+	case l == RslDurationT && isNumber(r) && (operator == STAR || operator == SLASH),
+		isNumber(l) && r == RslDurationT && operator == STAR:
+		return typeOf(RslDurationT)
+	}
+
+	c.error(token, fmt.Sprintf("Invalid binary operator '%s' for %s, %s", token.GetLexeme(), l.AsString(), r.AsString()))
+	return unknownType
+}
+
+// assign mirrors Env.SetAndExpectType and Env.SetAndImplyType
+func (c *TypeChecker) assign(identifier Token, expectedType *RslType, valueType *RslTypeEnum) {
+	if expectedType != nil {
+		expected := expectedType.Type
+		if isKnown(valueType) && *valueType != RslNullT && isScalar(expected) && *valueType != expected {
+			c.error(identifier, fmt.Sprintf("Type mismatch, expected %s, got %s",
+				expected.AsString(), valueType.AsString()))
+		}
+		if !isKnown(valueType) || *valueType != RslNullT {
+			valueType = typeOf(expected)
+		}
+	}
+	c.scope.set(identifier.GetLexeme(), valueType)
+}
+
+func (c *TypeChecker) iterableElementType(token Token, rangeType *RslTypeEnum, errMsg string) *RslTypeEnum {
+	if !isKnown(rangeType) {
+		return unknownType
+	}
+	switch *rangeType {
+	case RslStringArrayT:
+		return typeOf(RslStringT)
+	case RslIntArrayT:
+		return typeOf(RslIntT)
+	case RslFloatArrayT:
+		return typeOf(RslFloatT)
+	case RslBoolArrayT:
+		return typeOf(RslBoolT)
+	case RslArrayT, RslNullT:
+		return unknownType
+	default:
+		c.error(token, errMsg)
+		return unknownType
+	}
+}
+
+func (c *TypeChecker) defineLoopIdentifiers(identifier1 Token, identifier2 *Token, elementType *RslTypeEnum) {
+	if identifier2 != nil {
+		c.scope.set(identifier1.GetLexeme(), typeOf(RslIntT))
+		c.scope.set((*identifier2).GetLexeme(), elementType)
+	} else {
+		c.scope.set(identifier1.GetLexeme(), elementType)
+	}
+}
+
+func (c *TypeChecker) withChildScope(check func()) {
+	original := c.scope
+	c.scope = newTypeScope(original)
+	check()
+	for name := range c.scope.vars {
+		if _, ok := original.lookup(name); !ok {
+			c.endedBlockVars[name] = true
+		}
+	}
+	c.scope = original
+}
+
+// checkBranch checks code which may or may not run, so any variables it changes the type of are unknown after.
+func (c *TypeChecker) checkBranch(check func()) {
+	before := c.scope.snapshot()
+	check()
+	c.scope.forgetChangedSince(before)
+}
+
+// checkLoop checks a loop body, whose variables may have types from the previous iteration. A first silent
+// pass finds the variables it changes, which are then unknown when checking it for real.
+func (c *TypeChecker) checkLoop(check func()) {
+	c.repeating++
+	c.checkBranch(check)
+	c.repeating--
+	c.checkBranch(check)
+}
+
+func (s *typeScope) lookup(name string) (*RslTypeEnum, bool) {
+	for scope := s; scope != nil; scope = scope.enclosing {
+		if t, ok := scope.vars[name]; ok {
+			return t, true
+		}
+	}
+	return unknownType, false
+}
+
+// set assigns to the variable in the scope it's defined in, else defines it in this scope, like the Env.
+func (s *typeScope) set(name string, t *RslTypeEnum) {
+	for scope := s; scope != nil; scope = scope.enclosing {
+		if _, ok := scope.vars[name]; ok {
+			scope.vars[name] = t
+			return
+		}
+	}
+	s.vars[name] = t
+}
+
+func (s *typeScope) varNames() []string {
+	var names []string
+	for scope := s; scope != nil; scope = scope.enclosing {
+		names = append(names, lo.Keys(scope.vars)...)
+	}
+	return lo.Uniq(names)
+}
+
+func (s *typeScope) moduleNames() []string {
+	var names []string
+	for scope := s; scope != nil; scope = scope.enclosing {
+		names = append(names, lo.Keys(scope.modules)...)
+	}
+	return lo.Uniq(names)
+}
+
+func (s *typeScope) module(name string) (moduleExports, bool) {
+	for scope := s; scope != nil; scope = scope.enclosing {
+		if exports, ok := scope.modules[name]; ok {
+			return exports, true
+		}
+	}
+	return nil, false
+}
+
+func (s *typeScope) snapshot() map[*typeScope]map[string]*RslTypeEnum {
+	snapshot := make(map[*typeScope]map[string]*RslTypeEnum)
+	for scope := s; scope != nil; scope = scope.enclosing {
+		vars := make(map[string]*RslTypeEnum, len(scope.vars))
+		for name, t := range scope.vars {
+			vars[name] = t
+		}
+		snapshot[scope] = vars
+	}
+	return snapshot
+}
+
+func (s *typeScope) forgetChangedSince(snapshot map[*typeScope]map[string]*RslTypeEnum) {
+	for scope, vars := range snapshot {
+		for name, before := range vars {
+			now := scope.vars[name]
+			if !(before == nil && now == nil) && !sameType(before, now) {
+				scope.vars[name] = unknownType
+			}
+		}
+	}
+}
+
+func (c *TypeChecker) checkInterpolation(literal StringLiteral) {
+	if c.inChoiceSwitch > 0 {
+		// choice switches pick the option whose interpolated variables are all defined, so others needn't be
+		c.suppressed++
+		defer func() { c.suppressed-- }()
+	}
+
+	for _, interpolation := range literal.Interpolations {
+		valueType := c.typeOf(interpolation.Expr)
+		if interpolation.Format == nil || !isKnown(valueType) {
+			continue
+		}
+		if errMsg := interpolation.Format.mismatch(*valueType); errMsg != "" {
+			c.error(interpolation.Token, errMsg)
+		}
+	}
+}
@@ -67,3 +67,42 @@ func ToStringArray[T any](v []T) []string {
 	}
 	return output
 }
+
+// ToMixedArray converts any RSL array to a mixed array, so it can be worked on regardless of its element type.
+func ToMixedArray(v interface{}) ([]interface{}, bool) {
+	switch coerced := v.(type) {
+	case []string:
+		return AsMixedArray(coerced)
+	case []int64:
+		return AsMixedArray(coerced)
+	case []float64:
+		return AsMixedArray(coerced)
+	case []bool:
+		return AsMixedArray(coerced)
+	case []interface{}:
+		return coerced, true
+	default:
+		return nil, false
+	}
+}
+
+// AsArrayLike converts values back to the same type of array as like e.g. after sorting a []string as a mixed array.
+// The values must all fit that type.
+func AsArrayLike(like interface{}, values []interface{}) interface{} {
+	switch like.(type) {
+	case []string:
+		arr, _ := AsStringArray(values)
+		return arr
+	case []int64:
+		arr, _ := AsIntArray(values)
+		return arr
+	case []float64:
+		arr, _ := AsFloatArray(values)
+		return arr
+	case []bool:
+		arr, _ := AsBoolArray(values)
+		return arr
+	default:
+		return values
+	}
+}
@@ -7,12 +7,14 @@ require (
 	github.com/charmbracelet/huh v0.6.0
 	github.com/fatih/color v1.17.0
 	github.com/lithammer/fuzzysearch v1.1.8
+	github.com/nwidger/jsoncolor v0.3.2
 	github.com/samber/lo v1.47.0
 	github.com/scylladb/go-set v1.0.2
 	github.com/spf13/cobra v1.8.1
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/term v0.24.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -38,13 +40,11 @@ require (
 	github.com/muesli/ansi v0.0.0-20230316100256-276c6243b2f6 // indirect
 	github.com/muesli/cancelreader v0.2.2 // indirect
 	github.com/muesli/termenv v0.15.3-0.20240618155329-98d742f6907a // indirect
-	github.com/nwidger/jsoncolor v0.3.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	golang.org/x/sync v0.8.0 // indirect
 	golang.org/x/sys v0.26.0 // indirect
 	golang.org/x/text v0.18.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 // amterp: uncomment when devving on go-tbl